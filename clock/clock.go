@@ -1,6 +1,9 @@
 package clock
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 type PassiveClock interface {
 	Now() time.Time
@@ -13,21 +16,23 @@ type Clock interface {
 	NewTimer(d time.Duration) Timer
 	Sleep(d time.Duration)
 	Tick(d time.Duration) <-chan time.Time
+	NewTicker(d time.Duration) Ticker
+	AfterFunc(d time.Duration, f func()) Timer
 }
 
+// Deprecated: Clock includes NewTicker, use Clock instead.
 type WithTicker interface {
 	Clock
-	NewTicker(time.Duration) Ticker
 }
 
+// Deprecated: Clock includes AfterFunc, use Clock instead.
 type WithDelayExecution interface {
 	Clock
-	AfterFunc(time.Duration, func()) Timer
 }
 
+// Deprecated: Clock includes NewTicker and AfterFunc, use Clock instead.
 type WithTickerAndDelayedExecution interface {
-	WithTicker
-	AfterFunc(time.Duration, func()) Timer
+	Clock
 }
 
 type Ticker interface {
@@ -78,6 +83,11 @@ func (RealClock) Sleep(d time.Duration) {
 	time.Sleep(d)
 }
 
+// SleepContext is like Sleep, but returns ctx.Err() early if ctx is done.
+func (r RealClock) SleepContext(ctx context.Context, d time.Duration) error {
+	return SleepContext(ctx, r, d)
+}
+
 type Timer interface {
 	C() <-chan time.Time
 	Stop() bool
@@ -94,12 +104,40 @@ func (r *realTimer) C() <-chan time.Time {
 	return r.timer.C
 }
 
+// Stop prevents the Timer from firing. As with Go 1.23 timers, no stale value
+// is received from C after Stop returns, and Stop reports true if the timer
+// had fired but its value was not yet received.
 func (r *realTimer) Stop() bool {
-	return r.timer.Stop()
+	if r.timer.Stop() {
+		return true
+	}
+	return drain(r.timer.C)
 }
 
+// Reset changes the timer to expire after duration d, discarding any value
+// from the previous expiration that was not yet received.
 func (r *realTimer) Reset(d time.Duration) bool {
-	return r.timer.Reset(d)
+	active := r.Stop()
+	r.timer.Reset(d)
+	return active
+}
+
+// StopAndDrain stops t and discards a pending value from t.C(), if any, so
+// that t can be safely reused. Unlike the `if !t.Stop() { <-t.C() }` idiom it
+// never blocks, even if the value was already received.
+func StopAndDrain(t Timer) {
+	if !t.Stop() {
+		drain(t.C())
+	}
+}
+
+func drain(c <-chan time.Time) bool {
+	select {
+	case <-c:
+		return true
+	default:
+		return false
+	}
 }
 
 type realTicker struct {
@@ -0,0 +1,36 @@
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zhaoqiang0201/pkg/clock"
+	testingclock "github.com/zhaoqiang0201/pkg/clock/testing"
+)
+
+func TestTimerResetDiscardsStaleValue(t *testing.T) {
+	fc := testingclock.NewFakeClock(time.Now())
+	clocks := map[string]clock.Clock{
+		"real": clock.RealClock{},
+		"fake": fc,
+	}
+	for name, c := range clocks {
+		t.Run(name, func(t *testing.T) {
+			timer := c.NewTimer(time.Millisecond)
+			fc.Step(time.Millisecond)
+			time.Sleep(10 * time.Millisecond)
+
+			if !timer.Reset(time.Hour) {
+				t.Fatal("Reset of an unreceived timer should report true")
+			}
+			select {
+			case <-timer.C():
+				t.Fatal("received stale value after Reset")
+			default:
+			}
+
+			clock.StopAndDrain(timer)
+			clock.StopAndDrain(timer)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package clock
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// WithTimeout returns WithDeadline(parent, c, c.Now().Add(timeout)).
+func WithTimeout(parent context.Context, c Clock, timeout time.Duration) (context.Context, context.CancelFunc) {
+	return WithDeadline(parent, c, c.Now().Add(timeout))
+}
+
+// WithDeadline is like context.WithDeadline, but the deadline is measured
+// and fired by c instead of the runtime timer, so a fake clock can drive it.
+// Once the deadline passes, the context and the contexts derived from it
+// report context.DeadlineExceeded, also as their context.Cause.
+func WithDeadline(parent context.Context, c Clock, d time.Time) (context.Context, context.CancelFunc) {
+	switch c.(type) {
+	case RealClock, *RealClock:
+		return context.WithDeadline(parent, d)
+	}
+	if cur, ok := parent.Deadline(); ok && cur.Before(d) {
+		// The current deadline is already sooner than the new one.
+		return context.WithCancel(parent)
+	}
+
+	ctx, cancel := context.WithCancelCause(parent)
+	dc := &deadlineCtx{Context: ctx, deadline: d, done: make(chan struct{})}
+	dur := d.Sub(c.Now())
+	if dur <= 0 {
+		cancel(context.DeadlineExceeded)
+		dc.finish()
+		return dc, func() { cancel(context.Canceled); dc.finish() }
+	}
+
+	go dc.wait()
+	t := c.AfterFunc(dur, func() {
+		cancel(context.DeadlineExceeded)
+		dc.finish()
+	})
+	return dc, func() {
+		t.Stop()
+		cancel(context.Canceled)
+		dc.finish()
+	}
+}
+
+// deadlineCtx wraps the cancel context firing at the deadline. It has its own
+// Done channel, so that contexts derived from it do not attach to the inner
+// cancel context, which reports context.Canceled, but copy Err from here.
+type deadlineCtx struct {
+	context.Context
+	deadline time.Time
+
+	done chan struct{}
+	mu   sync.Mutex
+	err  error
+}
+
+// wait finishes c when the parent is done.
+func (c *deadlineCtx) wait() {
+	<-c.Context.Done()
+	c.finish()
+}
+
+// finish sets err from the inner context, once it is done, and closes done.
+// The deadline and the cancel func call it before returning, so that Err is
+// set as soon as they return, as with context.WithDeadline.
+func (c *deadlineCtx) finish() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return
+	}
+	err := c.Context.Err()
+	if err == nil {
+		return
+	}
+	if err == context.Canceled && context.Cause(c.Context) == context.DeadlineExceeded {
+		err = context.DeadlineExceeded
+	}
+	c.err = err
+	close(c.done)
+}
+
+func (c *deadlineCtx) Deadline() (time.Time, bool) {
+	return c.deadline, true
+}
+
+func (c *deadlineCtx) Done() <-chan struct{} {
+	return c.done
+}
+
+func (c *deadlineCtx) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+// SleepContext pauses the current goroutine for at least d on c. It returns
+// ctx.Err() early if ctx is done before d elapses, and nil otherwise.
+func SleepContext(ctx context.Context, c Clock, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if d <= 0 {
+		return nil
+	}
+	t := c.NewTimer(d)
+	select {
+	case <-ctx.Done():
+		StopAndDrain(t)
+		return ctx.Err()
+	case <-t.C():
+		return nil
+	}
+}
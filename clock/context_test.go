@@ -0,0 +1,135 @@
+package clock_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zhaoqiang0201/pkg/clock"
+	testingclock "github.com/zhaoqiang0201/pkg/clock/testing"
+)
+
+func TestWithTimeoutFakeClock(t *testing.T) {
+	fc := testingclock.NewFakeClock(time.Now())
+	ctx, cancel := clock.WithTimeout(context.Background(), fc, time.Minute)
+	defer cancel()
+
+	if d, ok := ctx.Deadline(); !ok || !d.Equal(fc.Now().Add(time.Minute)) {
+		t.Fatalf("unexpected deadline %v %v", d, ok)
+	}
+
+	fc.Step(59 * time.Second)
+	select {
+	case <-ctx.Done():
+		t.Fatal("context done before deadline")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	fc.Step(time.Second)
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("context not done after deadline")
+	}
+	if ctx.Err() != context.DeadlineExceeded {
+		t.Fatalf("expected DeadlineExceeded, got %v", ctx.Err())
+	}
+}
+
+func TestWithDeadlineChildren(t *testing.T) {
+	fc := testingclock.NewFakeClock(time.Now())
+	ctx, cancel := clock.WithTimeout(context.Background(), fc, time.Minute)
+	defer cancel()
+	child, cancelChild := context.WithCancel(ctx)
+	defer cancelChild()
+
+	fc.Step(time.Minute)
+	<-child.Done()
+	if err := child.Err(); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected DeadlineExceeded in child, got %v", err)
+	}
+	if err := context.Cause(child); err != context.DeadlineExceeded {
+		t.Fatalf("expected DeadlineExceeded cause, got %v", err)
+	}
+
+	late, cancelLate := context.WithCancel(ctx)
+	defer cancelLate()
+	if err := late.Err(); err != context.DeadlineExceeded {
+		t.Fatalf("expected DeadlineExceeded in late child, got %v", err)
+	}
+}
+
+func TestWithDeadlineRealClockPointer(t *testing.T) {
+	ctx, cancel := clock.WithTimeout(context.Background(), &clock.RealClock{}, time.Millisecond)
+	defer cancel()
+	<-ctx.Done()
+	if ctx.Err() != context.DeadlineExceeded {
+		t.Fatalf("expected DeadlineExceeded, got %v", ctx.Err())
+	}
+}
+
+func TestWithDeadlineCancel(t *testing.T) {
+	fc := testingclock.NewFakeClock(time.Now())
+	ctx, cancel := clock.WithDeadline(context.Background(), fc, fc.Now().Add(time.Minute))
+	cancel()
+
+	// Err is set when cancel returns, as with context.WithDeadline.
+	if ctx.Err() != context.Canceled {
+		t.Fatalf("expected Canceled, got %v", ctx.Err())
+	}
+	select {
+	case <-ctx.Done():
+	default:
+		t.Fatal("context not done after cancel")
+	}
+	if fc.HasWaiters() {
+		t.Fatal("timer not stopped on cancel")
+	}
+}
+
+func TestWithDeadlinePast(t *testing.T) {
+	fc := testingclock.NewFakeClock(time.Now())
+	ctx, cancel := clock.WithDeadline(context.Background(), fc, fc.Now().Add(-time.Second))
+	defer cancel()
+
+	if ctx.Err() != context.DeadlineExceeded {
+		t.Fatalf("expected DeadlineExceeded, got %v", ctx.Err())
+	}
+	select {
+	case <-ctx.Done():
+	default:
+		t.Fatal("context not done past its deadline")
+	}
+}
+
+func TestSleepContext(t *testing.T) {
+	fc := testingclock.NewFakeClock(time.Now())
+	ctx, cancel := context.WithCancel(context.Background())
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- fc.SleepContext(ctx, time.Hour)
+	}()
+	for !fc.HasWaiters() {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	if err := <-errCh; err != context.Canceled {
+		t.Fatalf("expected Canceled, got %v", err)
+	}
+	if fc.HasWaiters() {
+		t.Fatal("timer not stopped on cancel")
+	}
+
+	go func() {
+		errCh <- fc.SleepContext(context.Background(), time.Hour)
+	}()
+	for !fc.HasWaiters() {
+		time.Sleep(time.Millisecond)
+	}
+	fc.Step(time.Hour)
+	if err := <-errCh; err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+}
@@ -0,0 +1,117 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+var _ PassiveClock = &SkewedClock{}
+var _ PassiveClock = wallClock{}
+
+// SkewedClock is a PassiveClock that reports the time of a base clock shifted
+// by an offset and running faster or slower by a drift rate. It simulates the
+// wall clock of another node, or of this node across NTP adjustments.
+//
+// Readings carry no monotonic clock reading, so Sub and Since between them
+// observe the skew exactly as wall time would.
+type SkewedClock struct {
+	base PassiveClock
+
+	lock   sync.RWMutex
+	anchor time.Time
+	offset time.Duration
+	// drift 为每秒偏移的比例，例如 1e-4 表示每秒快 100µs
+	drift float64
+}
+
+// NewSkewedClock returns a SkewedClock over base with the given offset and
+// drift rate. A drift of 0.001 makes the clock gain 1ms every second.
+func NewSkewedClock(base PassiveClock, offset time.Duration, drift float64) *SkewedClock {
+	return &SkewedClock{
+		base:   base,
+		anchor: base.Now(),
+		offset: offset,
+		drift:  drift,
+	}
+}
+
+func (s *SkewedClock) Now() time.Time {
+	now := s.base.Now()
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.skewLocked(now)
+}
+
+func (s *SkewedClock) Since(ts time.Time) time.Duration {
+	return s.Now().Sub(ts)
+}
+
+// Jump moves the clock by d at once, like a step correction by NTP.
+func (s *SkewedClock) Jump(d time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.offset += d
+}
+
+// SetOffset sets the offset from the base clock, discarding the drift
+// accumulated so far.
+func (s *SkewedClock) SetOffset(offset time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.anchor = s.base.Now()
+	s.offset = offset
+}
+
+// SetDrift changes the drift rate from now on. The drift accumulated so far
+// is kept in the offset.
+func (s *SkewedClock) SetDrift(drift float64) {
+	now := s.base.Now()
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.offset = s.skewLocked(now).Sub(Wall(now))
+	s.anchor = now
+	s.drift = drift
+}
+
+// Offset returns the current difference between s and its base clock.
+func (s *SkewedClock) Offset() time.Duration {
+	now := s.base.Now()
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.skewLocked(now).Sub(Wall(now))
+}
+
+func (s *SkewedClock) skewLocked(now time.Time) time.Time {
+	drift := time.Duration(float64(now.Sub(s.anchor)) * s.drift)
+	return Wall(now).Add(s.offset + drift)
+}
+
+// Wall strips the monotonic clock reading from t, so that comparisons and
+// durations computed with it use wall clock time only.
+func Wall(t time.Time) time.Time {
+	return t.Round(0)
+}
+
+// WallSince returns the wall clock time elapsed since ts according to c,
+// ignoring monotonic readings. Unlike c.Since it reflects clock jumps.
+func WallSince(c PassiveClock, ts time.Time) time.Duration {
+	return Wall(c.Now()).Sub(Wall(ts))
+}
+
+// NewWallClock returns a PassiveClock whose readings have the monotonic clock
+// reading of base stripped.
+func NewWallClock(base PassiveClock) PassiveClock {
+	return wallClock{base: base}
+}
+
+type wallClock struct {
+	base PassiveClock
+}
+
+func (w wallClock) Now() time.Time {
+	return Wall(w.base.Now())
+}
+
+func (w wallClock) Since(ts time.Time) time.Duration {
+	return w.Now().Sub(ts)
+}
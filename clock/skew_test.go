@@ -0,0 +1,46 @@
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zhaoqiang0201/pkg/clock"
+	testingclock "github.com/zhaoqiang0201/pkg/clock/testing"
+)
+
+func TestSkewedClock(t *testing.T) {
+	start := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	base := testingclock.NewFakePassiveClock(start)
+	sc := clock.NewSkewedClock(base, time.Second, 0.001)
+
+	if got := sc.Now(); !got.Equal(start.Add(time.Second)) {
+		t.Fatalf("Now() = %v, want %v", got, start.Add(time.Second))
+	}
+
+	base.SetTime(start.Add(1000 * time.Second))
+	if got := sc.Offset(); got != 2*time.Second {
+		t.Fatalf("Offset() = %v, want 2s", got)
+	}
+
+	sc.SetDrift(0)
+	base.SetTime(start.Add(2000 * time.Second))
+	if got := sc.Offset(); got != 2*time.Second {
+		t.Fatalf("Offset() after SetDrift = %v, want 2s", got)
+	}
+
+	before := sc.Now()
+	sc.Jump(-time.Hour)
+	if got := sc.Since(before); got != -time.Hour {
+		t.Fatalf("Since() after Jump = %v, want -1h", got)
+	}
+}
+
+func TestWallSince(t *testing.T) {
+	now := time.Now()
+	if !now.Equal(clock.Wall(now)) || now == clock.Wall(now) {
+		t.Fatal("Wall should strip only the monotonic reading")
+	}
+	if d := clock.WallSince(clock.NewWallClock(clock.RealClock{}), now); d < 0 {
+		t.Fatalf("WallSince() = %v", d)
+	}
+}
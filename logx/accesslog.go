@@ -0,0 +1,212 @@
+package logx
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+	"github.com/zhaoqiang0201/pkg/clock"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/proto"
+)
+
+// AccessOption is AccessLog option.
+type AccessOption func(*accessOptions)
+
+type accessOptions struct {
+	helper    *Helper
+	clock     clock.PassiveClock
+	slow      time.Duration
+	routeSlow map[string]time.Duration
+	rate      float64
+	routeRate map[string]float64
+	args      bool
+	redactor  *Redactor
+	randFloat func() float64
+}
+
+// AccessHelper sets the Helper of the access log. By default entries go to
+// FromContext, so that the fields of ServerContext are included.
+func AccessHelper(h *Helper) AccessOption {
+	return func(o *accessOptions) {
+		o.helper = h
+	}
+}
+
+// AccessClock sets the clock measuring the latency.
+func AccessClock(c clock.PassiveClock) AccessOption {
+	return func(o *accessOptions) {
+		o.clock = c
+	}
+}
+
+// AccessSlowThreshold logs requests taking longer than d at WARN with
+// slow=true. 0, the default, disables it.
+func AccessSlowThreshold(d time.Duration) AccessOption {
+	return func(o *accessOptions) {
+		o.slow = d
+	}
+}
+
+// AccessRouteSlowThreshold overrides the slow threshold of operation.
+func AccessRouteSlowThreshold(operation string, d time.Duration) AccessOption {
+	return func(o *accessOptions) {
+		o.routeSlow[operation] = d
+	}
+}
+
+// AccessSampling logs the given fraction, between 0 and 1, of successful
+// requests. Failed and slow requests are always logged. The default is 1.
+func AccessSampling(rate float64) AccessOption {
+	return func(o *accessOptions) {
+		o.rate = rate
+	}
+}
+
+// AccessRouteSampling overrides the sampling rate of operation, e.g. 0 for
+// health checks.
+func AccessRouteSampling(operation string, rate float64) AccessOption {
+	return func(o *accessOptions) {
+		o.routeRate[operation] = rate
+	}
+}
+
+// AccessArgs logs the request as the args field, redacted by r. Proto
+// messages and structs are walked, so key rules match their field names.
+// With a nil r the args are logged as is, the Redact option of the Logger
+// still applies.
+func AccessArgs(r *Redactor) AccessOption {
+	return func(o *accessOptions) {
+		o.args = true
+		o.redactor = r
+	}
+}
+
+// AccessLog returns a kratos server middleware, for both HTTP and gRPC,
+// logging one entry per request:
+//
+//	kind operation code reason latency peer request.size [args] [slow] [error]
+//
+// code and reason are those of the kratos error, 200 and "" on success, and
+// latency is in seconds. Requests failing with a code of 500 and above are
+// logged at ERROR, other failures and slow requests at WARN, the rest at
+// INFO.
+func AccessLog(opts ...AccessOption) middleware.Middleware {
+	o := &accessOptions{
+		clock:     clock.RealClock{},
+		routeSlow: map[string]time.Duration{},
+		rate:      1,
+		routeRate: map[string]float64{},
+		randFloat: rand.Float64,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			start := o.clock.Now()
+			reply, err := handler(ctx, req)
+			latency := o.clock.Since(start)
+
+			var kind, operation string
+			tr, ok := transport.FromServerContext(ctx)
+			if ok {
+				kind, operation = tr.Kind().String(), tr.Operation()
+			}
+			code, reason := int32(200), ""
+			if err != nil {
+				e := kerrors.FromError(err)
+				code, reason = e.Code, e.Reason
+			}
+			slow := o.isSlow(operation, latency)
+
+			level := log.LevelInfo
+			switch {
+			case err != nil && code >= 500:
+				level = log.LevelError
+			case err != nil || slow:
+				level = log.LevelWarn
+			case !o.sample(operation):
+				return reply, err
+			}
+
+			kvs := []interface{}{
+				"kind", kind,
+				"operation", operation,
+				"code", code,
+				"reason", reason,
+				"latency", latency.Seconds(),
+				"peer", peerAddr(ctx, tr),
+				"request.size", requestSize(tr, req),
+			}
+			if o.args {
+				args := []interface{}{"args", req}
+				if o.redactor != nil {
+					args = o.redactor.Redact(args)
+				}
+				kvs = append(kvs, args...)
+			}
+			if slow {
+				kvs = append(kvs, "slow", true)
+			}
+			if err != nil {
+				kvs = append(kvs, "error", err.Error())
+			}
+			h := o.helper
+			if h == nil {
+				h = FromContext(ctx)
+			} else {
+				h = h.WithContext(ctx)
+			}
+			h.Log(level, kvs...)
+			return reply, err
+		}
+	}
+}
+
+func (o *accessOptions) isSlow(operation string, latency time.Duration) bool {
+	threshold, ok := o.routeSlow[operation]
+	if !ok {
+		threshold = o.slow
+	}
+	return threshold > 0 && latency > threshold
+}
+
+func (o *accessOptions) sample(operation string) bool {
+	rate, ok := o.routeRate[operation]
+	if !ok {
+		rate = o.rate
+	}
+	if rate >= 1 {
+		return true
+	}
+	return rate > 0 && o.randFloat() < rate
+}
+
+// peerAddr returns the remote address of the HTTP request or gRPC peer.
+func peerAddr(ctx context.Context, tr transport.Transporter) string {
+	if ht, ok := tr.(khttp.Transporter); ok && ht.Request() != nil {
+		return ht.Request().RemoteAddr
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+	return ""
+}
+
+// requestSize returns the size of the encoded request, the Content-Length of
+// HTTP requests and the proto size of gRPC requests. -1 if unknown.
+func requestSize(tr transport.Transporter, req interface{}) int64 {
+	if ht, ok := tr.(khttp.Transporter); ok && ht.Request() != nil && ht.Request().ContentLength >= 0 {
+		return ht.Request().ContentLength
+	}
+	if m, ok := req.(proto.Message); ok {
+		return int64(proto.Size(m))
+	}
+	return -1
+}
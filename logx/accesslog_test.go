@@ -0,0 +1,94 @@
+package logx
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/transport"
+	testingclock "github.com/zhaoqiang0201/pkg/clock/testing"
+)
+
+func TestAccessLog(t *testing.T) {
+	type loginRequest struct {
+		Name     string `json:"name"`
+		Password string `json:"password"`
+	}
+	r, err := NewRedactor([]*LogxRedactRule{{Key: "password"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc := testingclock.NewFakeClock(time.Now())
+	var buf bytes.Buffer
+	h := NewHelper(SetUpLog("", "", "", &LogxConf{Level: LogLevel_DEBUG}, Output(&buf)))
+	mw := AccessLog(
+		AccessHelper(h),
+		AccessClock(fc),
+		AccessSlowThreshold(time.Second),
+		AccessRouteSlowThreshold("/report", 10*time.Second),
+		AccessRouteSampling("/healthz", 0),
+		AccessArgs(r),
+	)
+
+	call := func(operation string, latency time.Duration, err error) {
+		ctx := transport.NewServerContext(context.Background(), newTestTransport(transport.KindGRPC, operation))
+		_, _ = mw(func(context.Context, interface{}) (interface{}, error) {
+			fc.Step(latency)
+			return "ok", err
+		})(ctx, &loginRequest{Name: "bob", Password: "hunter2"})
+	}
+	call("/login", 100*time.Millisecond, nil)
+	call("/healthz", 0, nil)
+	call("/login", 2*time.Second, nil)
+	call("/report", 2*time.Second, nil)
+	call("/healthz", 0, kerrors.BadRequest("BAD", "bad request"))
+	call("/login", 0, kerrors.InternalServer("DB", "db down"))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("got %d lines:\n%s", len(lines), buf.String())
+	}
+	if !strings.Contains(lines[0], "kind=grpc operation=/login code=200 reason=\"\" latency=0.1") ||
+		!strings.Contains(lines[0], "level=INFO") {
+		t.Errorf("unexpected entry: %s", lines[0])
+	}
+	if !strings.Contains(lines[0], "password:******") || strings.Contains(buf.String(), "hunter2") {
+		t.Errorf("args not redacted: %s", lines[0])
+	}
+	if !strings.Contains(lines[1], "slow=true") || !strings.Contains(lines[1], "level=WARN") {
+		t.Errorf("slow request not escalated: %s", lines[1])
+	}
+	if strings.Contains(lines[2], "slow") || !strings.Contains(lines[2], "operation=/report") {
+		t.Errorf("route threshold ignored: %s", lines[2])
+	}
+	if !strings.Contains(lines[3], "code=400 reason=BAD") || !strings.Contains(lines[3], "level=WARN") {
+		t.Errorf("failed request sampled or at wrong level: %s", lines[3])
+	}
+	if !strings.Contains(lines[4], "code=500 reason=DB") || !strings.Contains(lines[4], "level=ERROR") {
+		t.Errorf("server error at wrong level: %s", lines[4])
+	}
+}
+
+func TestAccessLogProtoArgs(t *testing.T) {
+	r, err := NewRedactor([]*LogxRedactRule{{Key: "aes_key"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	h := NewHelper(SetUpLog("", "", "", &LogxConf{Level: LogLevel_DEBUG}, Output(&buf)))
+	req := &LogxRedact{AesKey: "SECRETKEY", HashSalt: "salt"}
+	ctx := transport.NewServerContext(context.Background(), newTestTransport(transport.KindGRPC, "/logx.v1.Conf/Set"))
+	_, _ = AccessLog(AccessHelper(h), AccessArgs(r))(func(context.Context, interface{}) (interface{}, error) {
+		return nil, nil
+	})(ctx, req)
+
+	if strings.Contains(buf.String(), "SECRETKEY") || !strings.Contains(buf.String(), "aes_key:******") {
+		t.Errorf("proto args not redacted: %s", buf.String())
+	}
+	if !strings.Contains(buf.String(), "hash_salt:salt") || req.AesKey != "SECRETKEY" {
+		t.Errorf("args changed beyond the rule: %s", buf.String())
+	}
+}
@@ -0,0 +1,324 @@
+package logx
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/zhaoqiang0201/pkg/clock"
+)
+
+// ErrWriterClosed is returned when writing to a closed AsyncWriter.
+var ErrWriterClosed = errors.New("logx: writer closed")
+
+// LevelWriter is a writer that is told the level of each entry. The encoders
+// of Logger use WriteLevel instead of Write when the output implements it.
+type LevelWriter interface {
+	io.Writer
+	WriteLevel(level log.Level, p []byte) (n int, err error)
+}
+
+var _ LevelWriter = (*AsyncWriter)(nil)
+
+const (
+	defaultAsyncBufferSize    = 4096
+	defaultAsyncFlushInterval = time.Second
+	asyncBatchSize            = 32 * 1024
+)
+
+// AsyncOption is AsyncWriter option.
+type AsyncOption func(*AsyncWriter)
+
+// AsyncBufferSize sets how many entries the ring buffer holds.
+func AsyncBufferSize(size int) AsyncOption {
+	return func(a *AsyncWriter) {
+		if size > 0 {
+			a.ring = make([]asyncEntry, size)
+		}
+	}
+}
+
+// AsyncDropPolicy sets what happens to entries when the ring buffer is full.
+func AsyncDropPolicy(policy DropPolicy) AsyncOption {
+	return func(a *AsyncWriter) {
+		a.policy = policy
+	}
+}
+
+// AsyncFlushInterval sets how often buffered output is flushed to the
+// underlying writer.
+func AsyncFlushInterval(d time.Duration) AsyncOption {
+	return func(a *AsyncWriter) {
+		if d > 0 {
+			a.flushInterval = d
+		}
+	}
+}
+
+// AsyncClock sets the clock driving the periodic flush.
+func AsyncClock(c clock.Clock) AsyncOption {
+	return func(a *AsyncWriter) {
+		a.clock = c
+	}
+}
+
+type asyncEntry struct {
+	level log.Level
+	buf   *bytes.Buffer
+}
+
+// AsyncWriter writes to an underlying writer from a background goroutine.
+// Entries are queued in a bounded ring buffer, and when it is full they are
+// handled according to the DropPolicy. Call Close to flush the remaining
+// entries on shutdown.
+type AsyncWriter struct {
+	w             io.Writer
+	bw            *bufio.Writer
+	policy        DropPolicy
+	flushInterval time.Duration
+	clock         clock.Clock
+
+	mu      sync.Mutex
+	notFull *sync.Cond
+	ring    []asyncEntry
+	head    int
+	size    int
+	closed  bool
+
+	wake    chan struct{}
+	syncReq chan chan error
+	stop    chan struct{}
+	exited  chan struct{}
+	pool    sync.Pool
+	batch   []asyncEntry
+	pending int
+	err     error
+
+	dropped atomic.Uint64
+}
+
+// NewAsyncWriter returns an AsyncWriter writing to w and starts its
+// background goroutine.
+func NewAsyncWriter(w io.Writer, opts ...AsyncOption) *AsyncWriter {
+	a := &AsyncWriter{
+		w:             w,
+		bw:            bufio.NewWriterSize(w, asyncBatchSize),
+		policy:        DropPolicy_block,
+		flushInterval: defaultAsyncFlushInterval,
+		clock:         clock.RealClock{},
+		ring:          make([]asyncEntry, defaultAsyncBufferSize),
+		wake:          make(chan struct{}, 1),
+		syncReq:       make(chan chan error),
+		stop:          make(chan struct{}),
+		exited:        make(chan struct{}),
+		pool: sync.Pool{
+			New: func() interface{} {
+				return new(bytes.Buffer)
+			},
+		},
+	}
+	a.notFull = sync.NewCond(&a.mu)
+	for _, o := range opts {
+		o(a)
+	}
+	go a.run()
+	return a
+}
+
+// Write queues p as an INFO entry.
+func (a *AsyncWriter) Write(p []byte) (int, error) {
+	return a.WriteLevel(log.LevelInfo, p)
+}
+
+// WriteLevel queues p. p is copied, so the caller may reuse it.
+func (a *AsyncWriter) WriteLevel(level log.Level, p []byte) (int, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for !a.closed && a.size == len(a.ring) {
+		switch a.policy {
+		case DropPolicy_drop_newest:
+			a.dropped.Add(1)
+			return len(p), nil
+		case DropPolicy_drop_debug_first:
+			if a.dropDebugLocked() {
+				continue
+			}
+			if level <= log.LevelDebug {
+				a.dropped.Add(1)
+				return len(p), nil
+			}
+		}
+		a.notFull.Wait()
+	}
+	if a.closed {
+		return 0, ErrWriterClosed
+	}
+
+	buf := a.pool.Get().(*bytes.Buffer)
+	buf.Write(p)
+	a.ring[(a.head+a.size)%len(a.ring)] = asyncEntry{level: level, buf: buf}
+	a.size++
+	select {
+	case a.wake <- struct{}{}:
+	default:
+	}
+	return len(p), nil
+}
+
+// dropDebugLocked removes the oldest queued DEBUG entry, if any.
+func (a *AsyncWriter) dropDebugLocked() bool {
+	for i := 0; i < a.size; i++ {
+		idx := (a.head + i) % len(a.ring)
+		if a.ring[idx].level > log.LevelDebug {
+			continue
+		}
+		a.putBuffer(a.ring[idx].buf)
+		// 将后面的条目前移, 保持顺序
+		for j := i; j < a.size-1; j++ {
+			a.ring[(a.head+j)%len(a.ring)] = a.ring[(a.head+j+1)%len(a.ring)]
+		}
+		a.size--
+		a.ring[(a.head+a.size)%len(a.ring)] = asyncEntry{}
+		a.dropped.Add(1)
+		return true
+	}
+	return false
+}
+
+// Dropped returns the number of entries dropped because the buffer was full
+// or lost to an error of the underlying writer.
+func (a *AsyncWriter) Dropped() uint64 {
+	return a.dropped.Load()
+}
+
+// Sync writes all queued entries and flushes the underlying writer, calling
+// its Sync method if it has one. It returns the first write error since the
+// previous Sync, if any.
+func (a *AsyncWriter) Sync() error {
+	done := make(chan error, 1)
+	select {
+	case a.syncReq <- done:
+		return <-done
+	case <-a.exited:
+		return a.err
+	}
+}
+
+// Close stops accepting entries, writes the queued ones and closes the
+// underlying writer if it is an io.Closer, unless it is stdout or stderr.
+func (a *AsyncWriter) Close() error {
+	a.mu.Lock()
+	if a.closed {
+		a.mu.Unlock()
+		return nil
+	}
+	a.closed = true
+	a.notFull.Broadcast()
+	a.mu.Unlock()
+
+	close(a.stop)
+	<-a.exited
+	err := a.err
+	if cerr := closeWriter(a.w); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+func (a *AsyncWriter) run() {
+	defer close(a.exited)
+	ticker := a.clock.NewTicker(a.flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-a.wake:
+			a.drain()
+		case <-ticker.C():
+			a.drain()
+			a.flush()
+		case done := <-a.syncReq:
+			a.drain()
+			a.flush()
+			err := a.err
+			if err == nil {
+				err = syncWriter(a.w)
+			}
+			// 报告后清除错误, 之后的条目照常写入
+			a.err = nil
+			done <- err
+		case <-a.stop:
+			a.drain()
+			a.flush()
+			return
+		}
+	}
+}
+
+// drain writes the queued entries to the buffered writer.
+func (a *AsyncWriter) drain() {
+	for {
+		a.mu.Lock()
+		for a.size > 0 {
+			a.batch = append(a.batch, a.ring[a.head])
+			a.ring[a.head] = asyncEntry{}
+			a.head = (a.head + 1) % len(a.ring)
+			a.size--
+		}
+		a.notFull.Broadcast()
+		a.mu.Unlock()
+
+		if len(a.batch) == 0 {
+			return
+		}
+		for i, e := range a.batch {
+			a.writeEntry(e.buf.Bytes())
+			a.putBuffer(e.buf)
+			a.batch[i] = asyncEntry{}
+		}
+		a.batch = a.batch[:0]
+	}
+}
+
+func (a *AsyncWriter) putBuffer(buf *bytes.Buffer) {
+	buf.Reset()
+	a.pool.Put(buf)
+}
+
+// writeEntry writes p to the buffered writer, flushing the entries before it
+// first if p does not fit.
+func (a *AsyncWriter) writeEntry(p []byte) {
+	if len(p) > a.bw.Available() && a.bw.Buffered() > 0 {
+		a.flush()
+	}
+	if _, err := a.bw.Write(p); err != nil {
+		a.fail(err, a.pending+1)
+		return
+	}
+	if a.bw.Buffered() > 0 {
+		a.pending++
+	}
+}
+
+func (a *AsyncWriter) flush() {
+	if err := a.bw.Flush(); err != nil {
+		a.fail(err, a.pending)
+		return
+	}
+	a.pending = 0
+}
+
+// fail records err and counts the lost entries as dropped. bufio.Writer keeps
+// failing after an error, so it is reset to write the next entries again.
+func (a *AsyncWriter) fail(err error, lost int) {
+	if a.err == nil {
+		a.err = err
+	}
+	a.dropped.Add(uint64(lost))
+	a.pending = 0
+	a.bw.Reset(a.w)
+}
@@ -0,0 +1,87 @@
+package logx
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+type blockingWriter struct {
+	once    sync.Once
+	started chan struct{}
+	release chan struct{}
+	buf     bytes.Buffer
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	w.once.Do(func() {
+		close(w.started)
+		<-w.release
+	})
+	return w.buf.Write(p)
+}
+
+func TestAsyncWriterDropDebugFirst(t *testing.T) {
+	w := &blockingWriter{started: make(chan struct{}), release: make(chan struct{})}
+	a := NewAsyncWriter(w, AsyncBufferSize(2), AsyncDropPolicy(DropPolicy_drop_debug_first))
+
+	_, _ = a.WriteLevel(log.LevelInfo, []byte("a\n"))
+	go func() { _ = a.Sync() }()
+	<-w.started
+
+	_, _ = a.WriteLevel(log.LevelDebug, []byte("d1\n"))
+	_, _ = a.WriteLevel(log.LevelInfo, []byte("i1\n"))
+	_, _ = a.WriteLevel(log.LevelInfo, []byte("i2\n"))
+	_, _ = a.WriteLevel(log.LevelDebug, []byte("d2\n"))
+	if got := a.Dropped(); got != 2 {
+		t.Fatalf("Dropped() = %d, want 2", got)
+	}
+
+	close(w.release)
+	if err := a.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if got := w.buf.String(); got != "a\ni1\ni2\n" {
+		t.Fatalf("got %q", got)
+	}
+	if _, err := a.Write([]byte("late\n")); err != ErrWriterClosed {
+		t.Fatalf("Write after Close: %v", err)
+	}
+}
+
+type flakyWriter struct {
+	fails int
+	buf   bytes.Buffer
+}
+
+func (w *flakyWriter) Write(p []byte) (int, error) {
+	if w.fails > 0 {
+		w.fails--
+		return 0, errors.New("disk full")
+	}
+	return w.buf.Write(p)
+}
+
+func TestAsyncWriterRecoversFromWriteError(t *testing.T) {
+	w := &flakyWriter{fails: 1}
+	lx := NewConfLogger(&LogxConf{Async: &LogxAsync{}}, Output(w))
+	defer lx.Close()
+
+	_ = lx.Log(log.LevelInfo, "msg", "lost")
+	if err := lx.Sync(); err == nil {
+		t.Fatal("Sync did not report the write error")
+	}
+	_ = lx.Log(log.LevelInfo, "msg", "kept")
+	if err := lx.Sync(); err != nil {
+		t.Fatalf("write error not cleared: %v", err)
+	}
+	if got := w.buf.String(); got != "msg=kept level=INFO\n" {
+		t.Errorf("got %q", got)
+	}
+	if got := lx.Dropped(); got != 1 {
+		t.Errorf("Dropped() = %d, want 1", got)
+	}
+}
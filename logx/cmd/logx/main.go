@@ -0,0 +1,115 @@
+// Command logx works with the log files written by logx.
+//
+//	logx decrypt -keys keys.json [file ...]
+//
+// decrypt prints the files, or stdin, with the fields encrypted by the
+// redaction encrypt strategy replaced by their plaintext. keys.json is the
+// key file read by logx.LoadKeyring and must hold every key id found in the
+// files. Rotated .gz and .zst files are decompressed.
+package main
+
+import (
+	"bufio"
+	"compress/gzip"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/zhaogogo/pkg/logx"
+)
+
+func main() {
+	if len(os.Args) < 2 || os.Args[1] != "decrypt" {
+		fmt.Fprintln(os.Stderr, "usage: logx decrypt -keys <key file> [file ...]")
+		os.Exit(2)
+	}
+	if err := decrypt(os.Args[2:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func decrypt(args []string) error {
+	fs := flag.NewFlagSet("decrypt", flag.ExitOnError)
+	keys := fs.String("keys", "", "key file, see logx.LoadKeyring")
+	_ = fs.Parse(args)
+	if *keys == "" {
+		fs.Usage()
+		os.Exit(2)
+	}
+	k, err := logx.LoadKeyring(*keys)
+	if err != nil {
+		return err
+	}
+
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+	failed := 0
+	if fs.NArg() == 0 {
+		if failed, err = decryptStream(k, os.Stdin, out); err != nil {
+			return err
+		}
+	}
+	for _, name := range fs.Args() {
+		n, err := decryptFile(k, name, out)
+		if err != nil {
+			return err
+		}
+		failed += n
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d fields could not be decrypted", failed)
+	}
+	return nil
+}
+
+func decryptFile(k *logx.Keyring, name string, w io.Writer) (int, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	switch filepath.Ext(name) {
+	case ".gz":
+		zr, err := gzip.NewReader(f)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", name, err)
+		}
+		defer zr.Close()
+		r = zr
+	case ".zst":
+		zr, err := zstd.NewReader(f)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", name, err)
+		}
+		defer zr.Close()
+		r = zr
+	}
+	return decryptStream(k, r, w)
+}
+
+func decryptStream(k *logx.Keyring, r io.Reader, w io.Writer) (int, error) {
+	br := bufio.NewReader(r)
+	failed := 0
+	for {
+		line, err := br.ReadBytes('\n')
+		if len(line) > 0 {
+			out, n := logx.DecryptLine(k, line)
+			failed += n
+			if _, werr := w.Write(out); werr != nil {
+				return failed, werr
+			}
+		}
+		if err == io.EOF {
+			return failed, nil
+		}
+		if err != nil {
+			return failed, err
+		}
+	}
+}
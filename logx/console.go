@@ -0,0 +1,211 @@
+package logx
+
+import (
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/mattn/go-isatty"
+)
+
+var _ log.Logger = (*consoleLogger)(nil)
+
+const (
+	consoleTimeLayout = "15:04:05.000"
+
+	colorReset  = "\x1b[0m"
+	colorDim    = "\x1b[2m"
+	colorRed    = "\x1b[31m"
+	colorGreen  = "\x1b[32m"
+	colorYellow = "\x1b[33m"
+	colorCyan   = "\x1b[36m"
+	colorFatal  = "\x1b[1;41m"
+)
+
+// ConsoleOption is console encoder option.
+type ConsoleOption func(*consoleLogger)
+
+// ConsoleColor forces colors on or off. By default colors are used when the
+// writer is a terminal and NO_COLOR is not set.
+func ConsoleColor(color bool) ConsoleOption {
+	return func(l *consoleLogger) {
+		l.color = color
+	}
+}
+
+// consoleLogger renders entries for humans:
+//
+//	10:00:00.123 INF app/service/user.go:42 > user created id=7
+//
+// Fields with multi-line values, such as stack traces, follow on their own
+// indented lines.
+type consoleLogger struct {
+	entryWriter
+	color bool
+}
+
+// NewConsoleLogger returns the console encoder writing to w.
+func NewConsoleLogger(w io.Writer, opts ...ConsoleOption) log.Logger {
+	l := &consoleLogger{entryWriter: newEntryWriter(w), color: isTerminal(w)}
+	for _, o := range opts {
+		o(l)
+	}
+	return l
+}
+
+func isTerminal(w io.Writer) bool {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
+
+func (l *consoleLogger) Log(level log.Level, keyvals ...interface{}) error {
+	if (len(keyvals) & 1) == 1 {
+		keyvals = append(keyvals, "KEYVALS UNPAIRED")
+	}
+	var ts, caller, msg interface{}
+	for i := 0; i < len(keyvals); i += 2 {
+		switch keyvals[i] {
+		case "ts":
+			ts = keyvals[i+1]
+		case "caller":
+			caller = keyvals[i+1]
+		case DefaultMessageKey:
+			msg = keyvals[i+1]
+		}
+	}
+
+	bp := getBuffer()
+	buf := (*bp)[:0]
+	if ts != nil {
+		buf = l.paint(buf, colorDim, appendConsoleTime(nil, ts))
+		buf = append(buf, ' ')
+	}
+	buf = l.paint(buf, levelColor(level), []byte(levelBadge(level)))
+	if caller != nil {
+		buf = append(buf, ' ')
+		buf = l.paint(buf, colorDim, append(appendLogfmtValue(nil, caller), " >"...))
+	}
+	if msg != nil {
+		buf = append(buf, ' ')
+		if s, ok := msg.(string); ok {
+			buf = append(buf, s...)
+		} else {
+			buf = appendLogfmtValue(buf, msg)
+		}
+	}
+
+	var multiline []int
+	for i := 0; i < len(keyvals); i += 2 {
+		switch keyvals[i] {
+		case "ts", "caller", DefaultMessageKey:
+			continue
+		}
+		if s, ok := multilineValue(keyvals[i+1]); ok && s != "" {
+			multiline = append(multiline, i)
+			continue
+		}
+		buf = append(buf, ' ')
+		buf = l.paint(buf, colorCyan, append(appendLogfmtKey(nil, keyvals[i]), '='))
+		buf = appendLogfmtValue(buf, keyvals[i+1])
+	}
+	buf = append(buf, '\n')
+	for _, i := range multiline {
+		s, _ := multilineValue(keyvals[i+1])
+		buf = append(buf, "    "...)
+		buf = l.paint(buf, colorCyan, append(appendLogfmtKey(nil, keyvals[i]), ':'))
+		buf = append(buf, '\n')
+		for _, line := range strings.Split(strings.TrimRight(s, "\n"), "\n") {
+			buf = append(buf, "        "...)
+			buf = append(buf, line...)
+			buf = append(buf, '\n')
+		}
+	}
+	return l.writeEntry(level, bp, buf)
+}
+
+func (l *consoleLogger) paint(buf []byte, color string, s []byte) []byte {
+	if !l.color {
+		return append(buf, s...)
+	}
+	buf = append(buf, color...)
+	buf = append(buf, s...)
+	return append(buf, colorReset...)
+}
+
+// multilineValue returns the text of v if it spans several lines.
+func multilineValue(v interface{}) (string, bool) {
+	var s string
+	switch v := v.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	case error:
+		if isNilPtr(v) {
+			return "", false
+		}
+		s = v.Error()
+	default:
+		return "", false
+	}
+	return s, strings.IndexByte(s, '\n') >= 0
+}
+
+// appendConsoleTime appends the time of day of ts, the ts field in any of
+// the formats of Timestamp. Unknown formats are kept.
+func appendConsoleTime(buf []byte, ts interface{}) []byte {
+	switch v := ts.(type) {
+	case timestamp:
+		return v.t.AppendFormat(buf, consoleTimeLayout)
+	case time.Time:
+		return v.AppendFormat(buf, consoleTimeLayout)
+	case int64:
+		return time.UnixMilli(v).AppendFormat(buf, consoleTimeLayout)
+	case string:
+		if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+			return t.AppendFormat(buf, consoleTimeLayout)
+		}
+		return append(buf, v...)
+	}
+	return appendLogfmtValue(buf, ts)
+}
+
+func levelBadge(level log.Level) string {
+	switch level {
+	case log.LevelDebug:
+		return "DBG"
+	case log.LevelInfo:
+		return "INF"
+	case log.LevelWarn:
+		return "WRN"
+	case log.LevelError:
+		return "ERR"
+	case log.LevelFatal:
+		return "FTL"
+	default:
+		return "???"
+	}
+}
+
+func levelColor(level log.Level) string {
+	switch level {
+	case log.LevelDebug:
+		return colorCyan
+	case log.LevelInfo:
+		return colorGreen
+	case log.LevelWarn:
+		return colorYellow
+	case log.LevelError:
+		return colorRed
+	default:
+		return colorFatal
+	}
+}
@@ -0,0 +1,53 @@
+package logx
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+func TestConsoleLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewConsoleLogger(&buf)
+	_ = l.Log(log.LevelError,
+		"ts", "2026-10-19T10:00:00.123+08:00",
+		"caller", "app/service/user.go:42",
+		"user", "bob",
+		"msg", "create failed",
+		"stack", "main.main()\n\t/app/main.go:10\n",
+	)
+	want := "10:00:00.123 ERR app/service/user.go:42 > create failed user=bob\n" +
+		"    stack:\n" +
+		"        main.main()\n" +
+		"        \t/app/main.go:10\n"
+	if buf.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	buf.Reset()
+	l = NewConsoleLogger(&buf, ConsoleColor(true))
+	_ = l.Log(log.LevelWarn, "msg", "slow", "err", errors.New("timeout"))
+	want = colorYellow + "WRN" + colorReset + " slow " + colorCyan + "err=" + colorReset + "timeout\n"
+	if buf.String() != want {
+		t.Errorf("got  %q\nwant %q", buf.String(), want)
+	}
+
+	if isTerminal(&buf) {
+		t.Error("buffer reported as terminal")
+	}
+	buf.Reset()
+	_ = NewLogger(Output(&buf), Encoding(encoding_console)).Log(log.LevelInfo, "msg", "hi")
+	if buf.String() != "INF hi\n" {
+		t.Errorf("without terminal: %q", buf.String())
+	}
+}
+
+func TestConsoleLoggerTypedNil(t *testing.T) {
+	var buf bytes.Buffer
+	_ = NewConsoleLogger(&buf).Log(log.LevelError, "msg", (*nilStringer)(nil), "err", (*stackError)(nil), "s", (*nilStringer)(nil))
+	if want := "ERR <nil> err=<nil> s=<nil>\n"; buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
@@ -0,0 +1,112 @@
+package logx
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+)
+
+// DefaultRequestIDHeader is the header carrying the request id.
+const DefaultRequestIDHeader = "X-Request-Id"
+
+type helperKey struct{}
+
+// NewContext returns a copy of ctx carrying the Helper of ctx, see
+// FromContext, with keyvals added. Callees logging through FromContext get
+// the fields of every layer above them:
+//
+//	ctx = logx.NewContext(ctx, "user.id", uid, "tenant", tenant)
+//	logx.FromContext(ctx).Info("order created")
+func NewContext(ctx context.Context, keyvals ...interface{}) context.Context {
+	return context.WithValue(ctx, helperKey{}, helperFromContext(ctx).With(keyvals...))
+}
+
+// FromContext returns the Helper carried by ctx, or L if there is
+// none, bound to ctx for valuers such as the trace id.
+func FromContext(ctx context.Context) *Helper {
+	return helperFromContext(ctx).WithContext(ctx)
+}
+
+func helperFromContext(ctx context.Context) *Helper {
+	if h, ok := ctx.Value(helperKey{}).(*Helper); ok {
+		return h
+	}
+	return L()
+}
+
+// ContextOption is ServerContext option.
+type ContextOption func(*contextOptions)
+
+type contextOptions struct {
+	helper          *Helper
+	requestIDHeader string
+	fields          func(ctx context.Context) []interface{}
+}
+
+// ContextHelper sets the Helper carried by the request context, L by
+// default.
+func ContextHelper(h *Helper) ContextOption {
+	return func(o *contextOptions) {
+		o.helper = h
+	}
+}
+
+// ContextRequestID sets the header carrying the request id,
+// DefaultRequestIDHeader by default. An empty header disables request ids.
+func ContextRequestID(header string) ContextOption {
+	return func(o *contextOptions) {
+		o.requestIDHeader = header
+	}
+}
+
+// ContextFields adds the keyvals returned by fn, e.g. the user id and tenant
+// of an authenticated request. fn runs after the middlewares before
+// ServerContext, so it can read what they put into ctx.
+func ContextFields(fn func(ctx context.Context) []interface{}) ContextOption {
+	return func(o *contextOptions) {
+		o.fields = fn
+	}
+}
+
+// ServerContext returns a kratos server middleware putting a Helper into the
+// context of every request, with the fields operation, kind and request.id.
+// The request id is taken from the request header, or generated and set on
+// the reply header.
+func ServerContext(opts ...ContextOption) middleware.Middleware {
+	o := &contextOptions{requestIDHeader: DefaultRequestIDHeader}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			if o.helper != nil {
+				ctx = context.WithValue(ctx, helperKey{}, o.helper)
+			}
+			var kvs []interface{}
+			if tr, ok := transport.FromServerContext(ctx); ok {
+				kvs = append(kvs, "operation", tr.Operation(), "kind", tr.Kind().String())
+				if o.requestIDHeader != "" {
+					id := tr.RequestHeader().Get(o.requestIDHeader)
+					if id == "" {
+						id = newRequestID()
+						tr.ReplyHeader().Set(o.requestIDHeader, id)
+					}
+					kvs = append(kvs, "request.id", id)
+				}
+			}
+			if o.fields != nil {
+				kvs = append(kvs, o.fields(ctx)...)
+			}
+			return handler(NewContext(ctx, kvs...), req)
+		}
+	}
+}
+
+func newRequestID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
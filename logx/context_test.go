@@ -0,0 +1,85 @@
+package logx
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/transport"
+)
+
+type testHeader http.Header
+
+func (h testHeader) Get(key string) string      { return http.Header(h).Get(key) }
+func (h testHeader) Set(key, value string)      { http.Header(h).Set(key, value) }
+func (h testHeader) Add(key, value string)      { http.Header(h).Add(key, value) }
+func (h testHeader) Values(key string) []string { return http.Header(h).Values(key) }
+func (h testHeader) Keys() []string {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+type testTransport struct {
+	kind      transport.Kind
+	operation string
+	reqHeader testHeader
+	repHeader testHeader
+}
+
+func newTestTransport(kind transport.Kind, operation string) *testTransport {
+	return &testTransport{kind: kind, operation: operation, reqHeader: testHeader{}, repHeader: testHeader{}}
+}
+
+func (t *testTransport) Kind() transport.Kind            { return t.kind }
+func (t *testTransport) Endpoint() string                { return "" }
+func (t *testTransport) Operation() string               { return t.operation }
+func (t *testTransport) RequestHeader() transport.Header { return t.reqHeader }
+func (t *testTransport) ReplyHeader() transport.Header   { return t.repHeader }
+
+func TestContextFields(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewHelper(SetUpLog("", "", "", &LogxConf{Level: LogLevel_DEBUG}, Output(&buf)))
+	tr := newTestTransport(transport.KindHTTP, "/user.v1.User/Get")
+	tr.reqHeader.Set(DefaultRequestIDHeader, "req-1")
+	ctx := transport.NewServerContext(context.Background(), tr)
+
+	mw := ServerContext(ContextHelper(h), ContextFields(func(context.Context) []interface{} {
+		return []interface{}{"tenant", "acme"}
+	}))
+	_, _ = mw(func(ctx context.Context, _ interface{}) (interface{}, error) {
+		ctx = NewContext(ctx, "user.id", 7)
+		FromContext(ctx).Info("handled")
+		return nil, nil
+	})(ctx, nil)
+
+	want := "operation=/user.v1.User/Get kind=http request.id=req-1 tenant=acme user.id=7 msg=handled"
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("got %s, want %s", buf.String(), want)
+	}
+	if !strings.Contains(buf.String(), "/context_test.go:") {
+		t.Errorf("caller not the handler: %s", buf.String())
+	}
+	if FromContext(context.Background()).logger == h.logger {
+		t.Error("helper leaked out of the request context")
+	}
+}
+
+func TestServerContextRequestID(t *testing.T) {
+	tr := newTestTransport(transport.KindGRPC, "/user.v1.User/Get")
+	ctx := transport.NewServerContext(context.Background(), tr)
+	var fields []interface{}
+	_, _ = ServerContext()(func(ctx context.Context, _ interface{}) (interface{}, error) {
+		fields = helperFromContext(ctx).fields
+		return nil, nil
+	})(ctx, nil)
+
+	id := tr.repHeader.Get(DefaultRequestIDHeader)
+	if len(id) != 32 || fields[5] != id {
+		t.Errorf("request id %q not generated into %v", id, fields)
+	}
+}
@@ -0,0 +1,253 @@
+package logx
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// EncryptedPrefix starts every value encrypted by a Keyring, followed by the
+// key id, a colon and the base64 of nonce and ciphertext, e.g.
+// "enc:2026-10:q83v...".
+const EncryptedPrefix = "enc:"
+
+// DefaultKeyID is the key id used by NewAESEncryptor.
+const DefaultKeyID = "default"
+
+var (
+	// ErrUnknownKey is returned when decrypting a value encrypted with a key
+	// that is not in the Keyring.
+	ErrUnknownKey = errors.New("logx: unknown key id")
+	// ErrNotEncrypted is returned when decrypting a value that is not
+	// produced by a Keyring.
+	ErrNotEncrypted = errors.New("logx: value not encrypted")
+
+	keyIDRe     = regexp.MustCompile(`^[A-Za-z0-9_.\-]+$`)
+	encryptedRe = regexp.MustCompile(`enc:[A-Za-z0-9_.\-]+:[A-Za-z0-9+/]+`)
+)
+
+// Encryptor encrypts the values redacted with the encrypt strategy.
+type Encryptor interface {
+	Encrypt(plaintext []byte) (string, error)
+}
+
+var _ Encryptor = (*Keyring)(nil)
+
+// Keyring holds AES-GCM keys by id. Values are encrypted with the primary
+// key and carry its id, so after a rotation entries written with the old
+// keys can still be decrypted as long as the keys are kept in the ring.
+type Keyring struct {
+	mu      sync.RWMutex
+	keys    map[string]cipher.AEAD
+	primary string
+}
+
+// NewKeyring returns an empty Keyring.
+func NewKeyring() *Keyring {
+	return &Keyring{keys: make(map[string]cipher.AEAD)}
+}
+
+// NewAESEncryptor returns a Keyring holding the 16, 24 or 32 byte key as its
+// primary key with id DefaultKeyID.
+func NewAESEncryptor(key []byte) (*Keyring, error) {
+	k := NewKeyring()
+	if err := k.Rotate(DefaultKeyID, key); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// Add adds key with id to the ring, without making it the primary key. The
+// first key added becomes the primary key.
+func (k *Keyring) Add(id string, key []byte) error {
+	if !keyIDRe.MatchString(id) {
+		return fmt.Errorf("logx: invalid key id %q", id)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return fmt.Errorf("logx: key %q: %w", id, err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return fmt.Errorf("logx: key %q: %w", id, err)
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.keys[id] = aead
+	if k.primary == "" {
+		k.primary = id
+	}
+	return nil
+}
+
+// SetPrimary makes the key id encrypt new values.
+func (k *Keyring) SetPrimary(id string) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if _, ok := k.keys[id]; !ok {
+		return fmt.Errorf("%w %q", ErrUnknownKey, id)
+	}
+	k.primary = id
+	return nil
+}
+
+// Rotate adds key with id and makes it the primary key. The previous keys
+// are kept for decryption.
+func (k *Keyring) Rotate(id string, key []byte) error {
+	if err := k.Add(id, key); err != nil {
+		return err
+	}
+	return k.SetPrimary(id)
+}
+
+// Primary returns the id of the primary key.
+func (k *Keyring) Primary() string {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return k.primary
+}
+
+// Encrypt encrypts plaintext with the primary key. The key id is
+// authenticated along with the ciphertext.
+func (k *Keyring) Encrypt(plaintext []byte) (string, error) {
+	k.mu.RLock()
+	id, aead := k.primary, k.keys[k.primary]
+	k.mu.RUnlock()
+	if aead == nil {
+		return "", errors.New("logx: keyring has no key")
+	}
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, plaintext, []byte(id))
+	return EncryptedPrefix + id + ":" + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt decrypts a value returned by Encrypt.
+func (k *Keyring) Decrypt(s string) ([]byte, error) {
+	id, data, ok := strings.Cut(strings.TrimPrefix(s, EncryptedPrefix), ":")
+	if !ok || !strings.HasPrefix(s, EncryptedPrefix) {
+		return nil, ErrNotEncrypted
+	}
+	k.mu.RLock()
+	aead := k.keys[id]
+	k.mu.RUnlock()
+	if aead == nil {
+		return nil, fmt.Errorf("%w %q", ErrUnknownKey, id)
+	}
+	sealed, err := base64.RawStdEncoding.DecodeString(data)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return nil, ErrNotEncrypted
+	}
+	return aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], []byte(id))
+}
+
+// keyFile is the format read by LoadKeyring:
+//
+//	{"primary": "2026-10", "keys": {"2026-09": "<base64>", "2026-10": "<base64>"}}
+type keyFile struct {
+	Primary string            `json:"primary"`
+	Keys    map[string]string `json:"keys"`
+}
+
+// LoadKeyring reads a Keyring from a JSON key file mapping key ids to base64
+// keys. primary names the key encrypting new values, and may be omitted if
+// the file only holds one key.
+func LoadKeyring(name string) (*Keyring, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("logx: key file: %w", err)
+	}
+	var f keyFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("logx: key file %s: %w", name, err)
+	}
+	if f.Primary == "" && len(f.Keys) != 1 {
+		return nil, fmt.Errorf("logx: key file %s: primary not set", name)
+	}
+	k := NewKeyring()
+	for id, b64 := range f.Keys {
+		key, err := base64.StdEncoding.DecodeString(b64)
+		if err != nil {
+			return nil, fmt.Errorf("logx: key file %s: key %q: %w", name, id, err)
+		}
+		if err := k.Add(id, key); err != nil {
+			return nil, err
+		}
+	}
+	if f.Primary != "" {
+		if err := k.SetPrimary(f.Primary); err != nil {
+			return nil, err
+		}
+	}
+	return k, nil
+}
+
+// DecryptLine replaces the encrypted values in a log line with their
+// plaintext. Inside a quoted string, such as a JSON string or a quoted plain
+// value, the plaintext is escaped for that string; a value that is entirely
+// encrypted is quoted when needed in plain output. Values that cannot be
+// decrypted are kept, and the number of them is returned.
+func DecryptLine(k *Keyring, line []byte) ([]byte, int) {
+	isJSON := bytes.HasPrefix(bytes.TrimSpace(line), []byte("{"))
+	failed := 0
+	var out []byte
+	last := 0
+	// 扫描到 scanned 为止, inQuote 为该处是否位于引号内
+	scanned, inQuote := 0, false
+	for _, loc := range encryptedRe.FindAllIndex(line, -1) {
+		for ; scanned < loc[0]; scanned++ {
+			switch line[scanned] {
+			case '\\':
+				if inQuote {
+					scanned++
+				}
+			case '"':
+				inQuote = !inQuote
+			}
+		}
+		plain, err := k.Decrypt(string(line[loc[0]:loc[1]]))
+		if err != nil {
+			failed++
+			continue
+		}
+		out = append(out, line[last:loc[0]]...)
+		switch {
+		case inQuote && isJSON:
+			q := appendJSONString(nil, string(plain))
+			out = append(out, q[1:len(q)-1]...)
+		case inQuote:
+			q := strconv.Quote(string(plain))
+			out = append(out, q[1:len(q)-1]...)
+		case wholeValue(line, loc) && bytes.ContainsAny(plain, " \t\n\"="):
+			out = strconv.AppendQuote(out, string(plain))
+		default:
+			out = append(out, plain...)
+		}
+		last = loc[1]
+	}
+	if out == nil {
+		return line, failed
+	}
+	return append(out, line[last:]...), failed
+}
+
+// wholeValue reports whether the unquoted match at loc is an entire plain
+// value, from the '=' to the next space.
+func wholeValue(line []byte, loc []int) bool {
+	if loc[0] == 0 || line[loc[0]-1] != '=' {
+		return false
+	}
+	return loc[1] == len(line) || bytes.IndexByte([]byte(" \t\r\n"), line[loc[1]]) >= 0
+}
@@ -0,0 +1,113 @@
+package logx
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+func TestKeyringRotate(t *testing.T) {
+	k := NewKeyring()
+	if err := k.Add("k1", bytes.Repeat([]byte{1}, 32)); err != nil {
+		t.Fatal(err)
+	}
+	old, err := k.Encrypt([]byte("card 4111"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := k.Rotate("k2", bytes.Repeat([]byte{2}, 16)); err != nil {
+		t.Fatal(err)
+	}
+	cur, _ := k.Encrypt([]byte("card 4111"))
+	if !strings.HasPrefix(old, "enc:k1:") || !strings.HasPrefix(cur, "enc:k2:") {
+		t.Fatalf("key ids not embedded: %s, %s", old, cur)
+	}
+	for _, s := range []string{old, cur} {
+		if p, err := k.Decrypt(s); err != nil || string(p) != "card 4111" {
+			t.Errorf("Decrypt(%s) = %q, %v", s, p, err)
+		}
+	}
+
+	// 修改密钥 id 后认证失败
+	forged := "enc:k2:" + strings.TrimPrefix(old, "enc:k1:")
+	if _, err := k.Decrypt(forged); err == nil {
+		t.Error("Decrypt with swapped key id: want error")
+	}
+	if _, err := NewKeyring().Decrypt(cur); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("Decrypt without key: %v, want ErrUnknownKey", err)
+	}
+}
+
+func TestDecryptLine(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "keys.json")
+	key := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{7}, 32))
+	if err := os.WriteFile(name, []byte(`{"primary":"2026-10","keys":{"2026-10":"`+key+`"}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	k, err := LoadKeyring(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, _ := NewRedactor([]*LogxRedactRule{{Key: "args", Strategy: RedactStrategy_encrypt}}, RedactEncryptor(k))
+
+	for _, enc := range []string{encoding_json, encoding_plain} {
+		var buf bytes.Buffer
+		l := NewLogger(Output(&buf), Encoding(enc), Redact(r))
+		_ = l.Log(log.LevelInfo, "msg", "call", "args", `a "b"`)
+		if strings.Contains(buf.String(), "a ") {
+			t.Fatalf("%s: args not encrypted: %s", enc, buf.String())
+		}
+
+		got, failed := DecryptLine(k, buf.Bytes())
+		want := `"args":"a \"b\""`
+		if enc == encoding_plain {
+			want = `args="a \"b\""`
+		}
+		if failed != 0 || !strings.Contains(string(got), want) {
+			t.Errorf("%s: DecryptLine = %s, %d; want %s", enc, got, failed, want)
+		}
+
+		if _, failed := DecryptLine(NewKeyring(), buf.Bytes()); failed != 1 {
+			t.Errorf("%s: failed = %d without key, want 1", enc, failed)
+		}
+	}
+}
+
+func TestDecryptLineInsideString(t *testing.T) {
+	k, err := NewAESEncryptor(bytes.Repeat([]byte{7}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, _ := NewRedactor([]*LogxRedactRule{
+		{Value: PatternEmail, Strategy: RedactStrategy_encrypt},
+		{Value: `pw="[a-z]+"`, Strategy: RedactStrategy_encrypt},
+	}, RedactEncryptor(k))
+
+	for _, enc := range []string{encoding_json, encoding_plain} {
+		var buf bytes.Buffer
+		l := NewLogger(Output(&buf), Encoding(enc), Redact(r))
+		_ = l.Log(log.LevelInfo, "msg", `login a.b@example.com pw="abc" failed`)
+		if strings.Contains(buf.String(), "example.com") {
+			t.Fatalf("%s: email not encrypted: %s", enc, buf.String())
+		}
+
+		got, failed := DecryptLine(k, buf.Bytes())
+		want := `"message":"login a.b@example.com pw=\"abc\" failed"`
+		if enc == encoding_plain {
+			want = `msg="login a.b@example.com pw=\"abc\" failed"`
+		}
+		if failed != 0 || !strings.Contains(string(got), want) {
+			t.Errorf("%s: DecryptLine = %s, %d; want %s", enc, got, failed, want)
+		}
+		if enc == encoding_json && !json.Valid(got) {
+			t.Errorf("invalid JSON: %s", got)
+		}
+	}
+}
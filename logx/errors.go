@@ -0,0 +1,201 @@
+package logx
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+const maxStackDepth = 64
+
+// WithStack annotates err with the stack of its caller, rendered as
+// error.stack by ErrorFields. It returns nil if err is nil.
+func WithStack(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &stackError{err: err, pcs: callers(3)}
+}
+
+type stackError struct {
+	err error
+	pcs []uintptr
+}
+
+func (e *stackError) Error() string { return e.err.Error() }
+
+func (e *stackError) Unwrap() error { return e.err }
+
+// StackTrace returns the program counters of the stack, in the same shape as
+// github.com/pkg/errors.
+func (e *stackError) StackTrace() []uintptr { return e.pcs }
+
+func callers(skip int) []uintptr {
+	pcs := make([]uintptr, maxStackDepth)
+	return pcs[:runtime.Callers(skip, pcs)]
+}
+
+// ErrorFields returns the keyvals describing err:
+//
+//	error           err.Error()
+//	error.type      the Go type of err
+//	error.chain     the messages of the errors.Unwrap / errors.Join chain
+//	error.stack     the stack of the innermost error carrying one, from
+//	                WithStack or github.com/pkg/errors
+//	error.code      for kratos errors, with error.reason and the metadata
+//	                flattened to error.metadata.<key>
+func ErrorFields(err error) []interface{} {
+	if err == nil {
+		return []interface{}{"error", nil}
+	}
+	if isNilPtr(err) {
+		// 方法可能解引用 nil 接收者
+		return []interface{}{"error", "<nil>", "error.type", fmt.Sprintf("%T", err)}
+	}
+	kvs := []interface{}{"error", err.Error(), "error.type", fmt.Sprintf("%T", err)}
+
+	var chain []string
+	var stack []uintptr
+	var ke *kerrors.Error
+	// 不用 errors.As, 它会调用链中 nil 指针的 Unwrap
+	walkErrors(err, func(e error) {
+		chain = append(chain, e.Error())
+		if pcs, ok := stackTrace(e); ok {
+			stack = pcs
+		}
+		if k, ok := e.(*kerrors.Error); ok && ke == nil {
+			ke = k
+		}
+	})
+	if len(chain) > 1 {
+		kvs = append(kvs, "error.chain", chain)
+	}
+	if len(stack) > 0 {
+		kvs = append(kvs, "error.stack", formatFrames(stack))
+	}
+
+	if ke != nil {
+		kvs = append(kvs, "error.code", ke.Code, "error.reason", ke.Reason)
+		keys := make([]string, 0, len(ke.Metadata))
+		for k := range ke.Metadata {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			kvs = append(kvs, "error.metadata."+k, ke.Metadata[k])
+		}
+	}
+	return kvs
+}
+
+// walkErrors calls fn for err and the errors it wraps, depth first. It stops
+// at nil pointers.
+func walkErrors(err error, fn func(error)) {
+	for depth := 0; err != nil && !isNilPtr(err) && depth < maxStackDepth; depth++ {
+		fn(err)
+		switch e := err.(type) {
+		case interface{ Unwrap() []error }:
+			for _, je := range e.Unwrap() {
+				walkErrors(je, fn)
+			}
+			return
+		case interface{ Unwrap() error }:
+			err = e.Unwrap()
+		case interface{ Cause() error }:
+			// github.com/pkg/errors 的 withStack/withMessage
+			err = e.Cause()
+		default:
+			return
+		}
+	}
+}
+
+// stackTrace returns the program counters of e if it has a StackTrace
+// method returning a slice of uintptr based values, such as
+// github.com/pkg/errors.StackTrace.
+func stackTrace(e error) ([]uintptr, bool) {
+	m := reflect.ValueOf(e).MethodByName("StackTrace")
+	if !m.IsValid() || m.Type().NumIn() != 0 || m.Type().NumOut() != 1 {
+		return nil, false
+	}
+	if t := m.Type().Out(0); t.Kind() != reflect.Slice || t.Elem().Kind() != reflect.Uintptr {
+		return nil, false
+	}
+	st := m.Call(nil)[0]
+	pcs := make([]uintptr, st.Len())
+	for i := range pcs {
+		pcs[i] = uintptr(st.Index(i).Uint())
+	}
+	return pcs, len(pcs) > 0
+}
+
+// formatFrames renders pcs like runtime/debug.Stack: the function on one
+// line, file and line indented on the next. Leading frames for which skip
+// returns true are left out.
+func formatFrames(pcs []uintptr, skip ...func(fn string) bool) string {
+	var b strings.Builder
+	frames := runtime.CallersFrames(pcs)
+	leading := true
+	for {
+		f, more := frames.Next()
+		if leading && len(skip) > 0 && skip[0](f.Function) {
+			if !more {
+				break
+			}
+			continue
+		}
+		leading = false
+		if f.Function != "" {
+			fmt.Fprintf(&b, "%s\n\t%s:%d\n", f.Function, f.File, f.Line)
+		}
+		if !more {
+			break
+		}
+	}
+	return b.String()
+}
+
+// ErrorStack adds the stack of the logging goroutine as the "stack" field
+// to entries at level and above, e.g. log.LevelError.
+func ErrorStack(level log.Level) Option {
+	return func(l *Logger) {
+		l.stackLevel = level
+		l.errorStack = true
+	}
+}
+
+const (
+	logxPkg      = "github.com/zhaogogo/pkg/logx."
+	kratosLogPkg = "github.com/go-kratos/kratos/v2/log."
+)
+
+// loggingStack returns the stack of the caller of the logger, without the
+// frames of logx and kratos log.
+func loggingStack() string {
+	return formatFrames(callers(3), isLoggingFrame)
+}
+
+func isLoggingFrame(fn string) bool {
+	if strings.HasPrefix(fn, kratosLogPkg) {
+		return true
+	}
+	if !strings.HasPrefix(fn, logxPkg) {
+		return false
+	}
+	name := strings.TrimPrefix(fn, logxPkg)
+	if strings.HasPrefix(name, "(*") {
+		// Logger, Helper, sampledLogger 等的方法
+		return true
+	}
+	switch name {
+	case "Log", "Debug", "Debugf", "Debugw", "Info", "Infof", "Infow", "Warn", "Warnf", "Warnw",
+		"Error", "Errorf", "Errorw", "Fatal", "Fatalf", "Fatalw":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+package logx
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// pkgFrame 和 pkgStackError 模拟 github.com/pkg/errors 的 withStack
+type pkgFrame uintptr
+
+type pkgStackError struct {
+	error
+	stack []pkgFrame
+}
+
+func (e *pkgStackError) Cause() error { return e.error }
+
+func (e *pkgStackError) StackTrace() []pkgFrame { return e.stack }
+
+func newPkgStackError(err error) error {
+	var frames []pkgFrame
+	for _, pc := range callers(2) {
+		frames = append(frames, pkgFrame(pc))
+	}
+	return &pkgStackError{error: err, stack: frames}
+}
+
+func fieldMap(kvs []interface{}) map[string]interface{} {
+	m := make(map[string]interface{})
+	for i := 0; i+1 < len(kvs); i += 2 {
+		m[kvs[i].(string)] = kvs[i+1]
+	}
+	return m
+}
+
+func TestErrorFields(t *testing.T) {
+	base := kerrors.NotFound("USER_NOT_FOUND", "no such user").WithMetadata(map[string]string{"id": "7"})
+	err := fmt.Errorf("load: %w", errors.Join(WithStack(base), newPkgStackError(errors.New("cache miss"))))
+
+	f := fieldMap(ErrorFields(err))
+	if f["error"] != err.Error() || f["error.type"] != "*fmt.wrapError" {
+		t.Errorf("error = %v, type = %v", f["error"], f["error.type"])
+	}
+	chain, _ := f["error.chain"].([]string)
+	if len(chain) != 6 || chain[5] != "cache miss" {
+		t.Errorf("chain = %q", chain)
+	}
+	if s, _ := f["error.stack"].(string); !strings.Contains(s, "logx.newPkgStackError") || !strings.Contains(s, "errors_test.go:") {
+		t.Errorf("stack = %v", f["error.stack"])
+	}
+	if f["error.code"] != int32(404) || f["error.reason"] != "USER_NOT_FOUND" || f["error.metadata.id"] != "7" {
+		t.Errorf("kratos fields: %v", f)
+	}
+
+	f = fieldMap(ErrorFields(WithStack(errors.New("x"))))
+	if s, _ := f["error.stack"].(string); !strings.HasPrefix(s, "github.com/zhaogogo/pkg/logx.TestErrorFields\n") {
+		t.Errorf("WithStack stack = %v", f["error.stack"])
+	}
+}
+
+func TestErrorStack(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewHelper(NewLogger(Output(&buf), Encoding(encoding_console), ErrorStack(log.LevelError)))
+	h.Warn("warned")
+	h.Errorf("with stack")
+
+	lines := strings.Split(buf.String(), "\n")
+	if strings.Contains(lines[0], "stack") {
+		t.Errorf("stack at WARN: %s", lines[0])
+	}
+	if lines[2] != "    stack:" || !strings.HasPrefix(lines[3], "        github.com/zhaogogo/pkg/logx.TestErrorStack") {
+		t.Errorf("stack at ERROR:\n%s", buf.String())
+	}
+}
+
+func TestErrorFieldsTypedNil(t *testing.T) {
+	f := fieldMap(ErrorFields((*stackError)(nil)))
+	if f["error"] != "<nil>" || f["error.type"] != "*logx.stackError" {
+		t.Errorf("typed nil: %v", f)
+	}
+	f = fieldMap(ErrorFields(fmt.Errorf("load: %w", (*stackError)(nil))))
+	if chain, _ := f["error.chain"].([]string); f["error"] != "load: <nil>" || chain != nil {
+		t.Errorf("wrapped typed nil: %v", f)
+	}
+
+	var buf bytes.Buffer
+	NewHelper(newPlainLogger(&buf)).Error((*stackError)(nil))
+	if !strings.Contains(buf.String(), "error=<nil>") {
+		t.Errorf("got %s", buf.String())
+	}
+}
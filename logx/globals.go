@@ -0,0 +1,74 @@
+package logx
+
+import (
+	"sync/atomic"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// globals is the Helper of the package-level functions and the logger it
+// installed as the kratos global logger.
+type globals struct {
+	helper *Helper
+	kratos log.Logger
+}
+
+var defaultGlobals atomic.Pointer[globals]
+
+// Default is the Helper of the package-level functions, kept in sync by
+// SetDefault.
+//
+// Deprecated: Reading Default races with SetDefault and assigning it does
+// not change the package-level functions. Use L and SetDefault.
+var Default *Helper
+
+func init() {
+	Default = NewHelper(SetUpLog("", "", "", &LogxConf{
+		Encoding: Encode_plain,
+		Level:    LogLevel_DEBUG,
+	}))
+	defaultGlobals.Store(&globals{helper: Default, kratos: log.DefaultLogger})
+}
+
+// L returns the Helper used by the package-level functions. Until SetDefault
+// or ReplaceGlobals is called it logs plain entries at DEBUG to stdout.
+func L() *Helper {
+	return defaultGlobals.Load().helper
+}
+
+// SetDefault makes h the Helper of the package-level functions and its
+// logger, with the fields of h, the kratos global logger. It is safe for
+// concurrent use. The returned func restores the previous Helper and kratos
+// logger:
+//
+//	defer logx.SetDefault(logx.NewHelper(logger))()
+//
+// The kratos logger restored by the first SetDefault is log.DefaultLogger,
+// not one the app installed with log.SetLogger before: log.GetLogger only
+// returns the proxy of the kratos global logger, not the logger behind it.
+func SetDefault(h *Helper) (restore func()) {
+	kl := h.logger
+	if len(h.fields) > 0 {
+		kl = log.With(kl, h.fields...)
+	}
+	prev := defaultGlobals.Swap(&globals{helper: h, kratos: kl})
+	Default = h
+	log.SetLogger(kl)
+	return func() {
+		defaultGlobals.Store(prev)
+		Default = prev.helper
+		log.SetLogger(prev.kratos)
+	}
+}
+
+// ReplaceGlobals builds the service logger from c like SetUpLog and installs
+// it with SetDefault. The returned func restores the previous globals and
+// closes the logger.
+func ReplaceGlobals(serviceId string, serviceName string, serviceVersion string, c *LogxConf, opts ...Option) (restore func()) {
+	lx := NewConfLogger(c, opts...)
+	undo := SetDefault(NewHelper(WithService(lx, serviceId, serviceName, serviceVersion)))
+	return func() {
+		undo()
+		_ = lx.Close()
+	}
+}
@@ -0,0 +1,63 @@
+package logx
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+func TestReplaceGlobals(t *testing.T) {
+	prev := L()
+	var buf bytes.Buffer
+	restore := ReplaceGlobals("1", "svc", "v1", &LogxConf{Level: LogLevel_INFO}, Output(&buf))
+
+	Debug("hidden")
+	Infof("from %s", "logx")
+	log.Warn("from kratos")
+	if Default != L() {
+		t.Error("Default not kept in sync")
+	}
+	restore()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines:\n%s", len(lines), buf.String())
+	}
+	for _, line := range lines {
+		if !strings.Contains(line, "service.name=svc") {
+			t.Errorf("service fields missing: %s", line)
+		}
+	}
+	if !strings.Contains(lines[0], "/globals_test.go:") || !strings.Contains(lines[1], "from kratos") {
+		t.Errorf("unexpected entries:\n%s", buf.String())
+	}
+	if L() != prev || Default != prev {
+		t.Error("restore did not bring back the previous L")
+	}
+	if log.GetLogger().Log(log.LevelInfo, "msg", "after restore"); strings.Contains(buf.String(), "after restore") {
+		t.Error("restore did not bring back the previous kratos logger")
+	}
+}
+
+func TestSetDefaultConcurrent(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewHelper(SetUpLog("", "", "", &LogxConf{Level: LogLevel_DEBUG}, Output(&buf))).With("k", "v")
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = L().With("n", 1)
+		}()
+	}
+	restore := SetDefault(h)
+	Warn("swapped")
+	wg.Wait()
+	restore()
+	if !strings.Contains(buf.String(), "k=v msg=swapped") {
+		t.Errorf("L not swapped: %s", buf.String())
+	}
+}
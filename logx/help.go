@@ -3,7 +3,6 @@ package logx
 import (
 	"context"
 	"fmt"
-	"github.com/go-kratos/kratos/v2/errors"
 	"github.com/go-kratos/kratos/v2/log"
 	"os"
 )
@@ -18,7 +17,7 @@ type HelpOption func(*Helper)
 type Helper struct {
 	logger  log.Logger
 	msgKey  string
-	field   []string
+	fields  []interface{}
 	sprint  func(...interface{}) string
 	sprintf func(format string, a ...interface{}) string
 }
@@ -64,112 +63,132 @@ func (h *Helper) WithContext(ctx context.Context) *Helper {
 	return &Helper{
 		msgKey:  h.msgKey,
 		logger:  log.WithContext(ctx, h.logger),
-		field:   h.field,
+		fields:  h.fields,
 		sprint:  h.sprint,
 		sprintf: h.sprintf,
 	}
 }
 
-func (h *Helper) WithField(fields ...string) *Helper {
-	if len(fields)%2 != 0 {
+// With returns a copy of h adding keyvals to every entry. h is not
+// changed. A missing value of the last key is set to "".
+func (h *Helper) With(keyvals ...interface{}) *Helper {
+	fields := make([]interface{}, 0, len(h.fields)+len(keyvals)+1)
+	fields = append(fields, h.fields...)
+	fields = append(fields, keyvals...)
+	if len(keyvals)%2 != 0 {
 		fields = append(fields, "")
 	}
-	h.field = fields
-	return h
+	return &Helper{
+		msgKey:  h.msgKey,
+		logger:  h.logger,
+		fields:  fields,
+		sprint:  h.sprint,
+		sprintf: h.sprintf,
+	}
+}
+
+// WithField returns a copy of h adding fields, as key value pairs, to every
+// entry.
+//
+// Deprecated: use With.
+func (h *Helper) WithField(fields ...string) *Helper {
+	kvs := make([]interface{}, len(fields))
+	for i, f := range fields {
+		kvs[i] = f
+	}
+	return h.With(kvs...)
+}
+
+// kvs returns the fields of h followed by keyvals. The level methods call
+// h.logger.Log directly with it, so that Caller sees their caller.
+func (h *Helper) kvs(keyvals ...interface{}) []interface{} {
+	if len(h.fields) == 0 {
+		return keyvals
+	}
+	kvs := make([]interface{}, 0, len(h.fields)+len(keyvals))
+	kvs = append(kvs, h.fields...)
+	return append(kvs, keyvals...)
 }
 
 // Log Print log by level and keyvals.
 func (h *Helper) Log(level log.Level, keyvals ...interface{}) {
-	kvs := []interface{}{}
-	kvs = append(kvs, h.field, keyvals)
-	_ = h.logger.Log(level, kvs...)
+	_ = h.logger.Log(level, h.kvs(keyvals...)...)
 }
 
 // Debug logs a message at debug level.
 func (h *Helper) Debug(a ...interface{}) {
-	_ = h.logger.Log(log.LevelDebug, h.msgKey, h.sprint(a...))
+	_ = h.logger.Log(log.LevelDebug, h.kvs(h.msgKey, h.sprint(a...))...)
 }
 
 // Debugf logs a message at debug level.
 func (h *Helper) Debugf(format string, a ...interface{}) {
-	_ = h.logger.Log(log.LevelDebug, h.msgKey, h.sprintf(format, a...))
+	_ = h.logger.Log(log.LevelDebug, h.kvs(h.msgKey, h.sprintf(format, a...))...)
 }
 
 // Debugw logs a message at debug level.
 func (h *Helper) Debugw(keyvals ...interface{}) {
-	_ = h.logger.Log(log.LevelDebug, keyvals...)
+	_ = h.logger.Log(log.LevelDebug, h.kvs(keyvals...)...)
 }
 
 // Info logs a message at info level.
 func (h *Helper) Info(a ...interface{}) {
-	_ = h.logger.Log(log.LevelInfo, h.msgKey, h.sprint(a...))
+	_ = h.logger.Log(log.LevelInfo, h.kvs(h.msgKey, h.sprint(a...))...)
 }
 
 // Infof logs a message at info level.
 func (h *Helper) Infof(format string, a ...interface{}) {
-	_ = h.logger.Log(log.LevelInfo, h.msgKey, h.sprintf(format, a...))
+	_ = h.logger.Log(log.LevelInfo, h.kvs(h.msgKey, h.sprintf(format, a...))...)
 }
 
 // Infow logs a message at info level.
 func (h *Helper) Infow(keyvals ...interface{}) {
-	_ = h.logger.Log(log.LevelInfo, keyvals...)
+	_ = h.logger.Log(log.LevelInfo, h.kvs(keyvals...)...)
 }
 
 // Warn logs a message at warn level.
 func (h *Helper) Warn(a ...interface{}) {
-	_ = h.logger.Log(log.LevelWarn, h.msgKey, h.sprint(a...))
+	_ = h.logger.Log(log.LevelWarn, h.kvs(h.msgKey, h.sprint(a...))...)
 }
 
 // Warnf logs a message at warnf level.
 func (h *Helper) Warnf(format string, a ...interface{}) {
-	_ = h.logger.Log(log.LevelWarn, h.msgKey, h.sprintf(format, a...))
+	_ = h.logger.Log(log.LevelWarn, h.kvs(h.msgKey, h.sprintf(format, a...))...)
 }
 
 // Warnw logs a message at warnf level.
 func (h *Helper) Warnw(keyvals ...interface{}) {
-	_ = h.logger.Log(log.LevelWarn, keyvals...)
+	_ = h.logger.Log(log.LevelWarn, h.kvs(keyvals...)...)
 }
 
-// Error logs a message at error level.
+// Error logs a at error level, rendered by ErrorFields.
 func (h *Helper) Error(a error) {
-	kvs := []interface{}{}
-	for _, v := range h.field {
-		kvs = append(kvs, v)
-	}
-	switch a.(type) {
-	case *errors.Error:
-		ee := a.(*errors.Error)
-		kvs = append(kvs, "code", ee.Code, "reason", ee.Reason, "message", ee.Message, "metadata", ee.Metadata, "cause", ee.Unwrap())
-	default:
-		kvs = append(kvs, "error", a)
-	}
-	_ = h.logger.Log(log.LevelError, kvs...)
+	_ = h.logger.Log(log.LevelError, h.kvs(ErrorFields(a)...)...)
 }
 
 // Errorf logs a message at error level.
 func (h *Helper) Errorf(format string, a ...interface{}) {
-	_ = h.logger.Log(log.LevelError, h.msgKey, h.sprintf(format, a...))
+	_ = h.logger.Log(log.LevelError, h.kvs(h.msgKey, h.sprintf(format, a...))...)
 }
 
 // Errorw logs a message at error level.
 func (h *Helper) Errorw(keyvals ...interface{}) {
-	_ = h.logger.Log(log.LevelError, keyvals...)
+	_ = h.logger.Log(log.LevelError, h.kvs(keyvals...)...)
 }
 
 // Fatal logs a message at fatal level.
 func (h *Helper) Fatal(a ...interface{}) {
-	_ = h.logger.Log(log.LevelFatal, h.msgKey, h.sprint(a...))
+	_ = h.logger.Log(log.LevelFatal, h.kvs(h.msgKey, h.sprint(a...))...)
 	os.Exit(1)
 }
 
 // Fatalf logs a message at fatal level.
 func (h *Helper) Fatalf(format string, a ...interface{}) {
-	_ = h.logger.Log(log.LevelFatal, h.msgKey, h.sprintf(format, a...))
+	_ = h.logger.Log(log.LevelFatal, h.kvs(h.msgKey, h.sprintf(format, a...))...)
 	os.Exit(1)
 }
 
 // Fatalw logs a message at fatal level.
 func (h *Helper) Fatalw(keyvals ...interface{}) {
-	_ = h.logger.Log(log.LevelFatal, keyvals...)
+	_ = h.logger.Log(log.LevelFatal, h.kvs(keyvals...)...)
 	os.Exit(1)
 }
@@ -0,0 +1,44 @@
+package logx
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+func TestHelperWith(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewHelper(SetUpLog("", "svc", "", &LogxConf{Level: LogLevel_DEBUG}, Output(&buf)))
+	rh := h.With("request", 7, "admin", true)
+	_ = h.WithField("ignored", "x")
+
+	rh.Infof("hi %s", "bob")
+	rh.Warn("careful")
+	rh.Log(log.LevelDebug, "k", "v")
+	h.With("odd").Info("x")
+	h.Info("plain")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("got %d lines:\n%s", len(lines), buf.String())
+	}
+	for _, line := range lines[:3] {
+		if !strings.Contains(line, "request=7 admin=true") {
+			t.Errorf("fields missing: %s", line)
+		}
+		if !strings.Contains(line, "/help_test.go:") {
+			t.Errorf("caller not the test: %s", line)
+		}
+	}
+	if !strings.Contains(lines[2], "admin=true k=v") {
+		t.Errorf("Log keyvals not spread: %s", lines[2])
+	}
+	if !strings.Contains(lines[3], `odd="" msg=x`) {
+		t.Errorf("unpaired field: %s", lines[3])
+	}
+	if strings.Contains(lines[4], "request") || strings.Contains(buf.String(), "ignored") {
+		t.Errorf("With or WithField changed the helper: %s", lines[4])
+	}
+}
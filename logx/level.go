@@ -0,0 +1,128 @@
+package logx
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/go-kratos/kratos/v2/config"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// AtomicLevel is a log level that can be changed at runtime and shared by
+// several loggers.
+type AtomicLevel struct {
+	l atomic.Int32
+}
+
+// NewAtomicLevel returns an AtomicLevel set to level.
+func NewAtomicLevel(level log.Level) *AtomicLevel {
+	al := &AtomicLevel{}
+	al.SetLevel(level)
+	return al
+}
+
+// Level returns the current level.
+func (al *AtomicLevel) Level() log.Level {
+	return log.Level(al.l.Load())
+}
+
+// SetLevel changes the level.
+func (al *AtomicLevel) SetLevel(level log.Level) {
+	al.l.Store(int32(level))
+}
+
+// Enabled reports whether level is at or above the current level.
+func (al *AtomicLevel) Enabled(level log.Level) bool {
+	return level >= al.Level()
+}
+
+type levelPayload struct {
+	Level string `json:"level"`
+}
+
+type errorPayload struct {
+	Error string `json:"error"`
+}
+
+// ServeHTTP returns the current level as JSON on GET, and changes it on PUT
+// with a JSON body like {"level":"DEBUG"}.
+func (al *AtomicLevel) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	enc := json.NewEncoder(w)
+	w.Header().Set("Content-Type", "application/json")
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		var req levelPayload
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = enc.Encode(errorPayload{Error: fmt.Sprintf("decode request body: %v", err)})
+			return
+		}
+		level, err := ParseLevel(req.Level)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = enc.Encode(errorPayload{Error: err.Error()})
+			return
+		}
+		al.SetLevel(level)
+	default:
+		w.Header().Set("Allow", "GET, PUT")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		_ = enc.Encode(errorPayload{Error: "only GET and PUT are supported"})
+		return
+	}
+	_ = enc.Encode(levelPayload{Level: al.Level().String()})
+}
+
+// ParseLevel parses a level name such as "debug" or "WARN". Unlike
+// log.ParseLevel it rejects unknown names instead of falling back to INFO.
+func ParseLevel(s string) (log.Level, error) {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "DEBUG":
+		return log.LevelDebug, nil
+	case "INFO":
+		return log.LevelInfo, nil
+	case "WARN":
+		return log.LevelWarn, nil
+	case "ERROR":
+		return log.LevelError, nil
+	case "FATAL":
+		return log.LevelFatal, nil
+	}
+	return 0, fmt.Errorf("logx: unknown level %q", s)
+}
+
+// toLevel converts the LogxConf level to a kratos log level.
+func toLevel(l LogLevel) log.Level {
+	return log.ParseLevel(LogLevel_name[int32(l)])
+}
+
+// WatchLevel updates al whenever the LogLevel under key of c changes, e.g.
+// key "log.level" for a LogxConf loaded at "log". Both level names and enum
+// numbers are accepted, invalid values are logged and ignored. To update a
+// Logger built from the LogxConf, pass its Level, or an AtomicLevel given to
+// it with SharedLevel.
+func WatchLevel(c config.Config, key string, al *AtomicLevel) error {
+	return c.Watch(key, func(_ string, v config.Value) {
+		s, err := v.String()
+		if err != nil {
+			L().Errorf("logx: watch level %s: %v", key, err)
+			return
+		}
+		level, err := ParseLevel(s)
+		if err != nil {
+			n, nerr := strconv.Atoi(s)
+			if _, ok := LogLevel_name[int32(n)]; nerr != nil || !ok {
+				L().Errorf("logx: watch level %s: %v", key, err)
+				return
+			}
+			level = toLevel(LogLevel(n))
+		}
+		al.SetLevel(level)
+	})
+}
@@ -0,0 +1,108 @@
+package logx
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/config"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+func TestAtomicLevelServeHTTP(t *testing.T) {
+	al := NewAtomicLevel(log.LevelInfo)
+	tests := []struct {
+		method string
+		body   string
+		code   int
+		want   string
+		level  log.Level
+	}{
+		{http.MethodGet, "", http.StatusOK, `{"level":"INFO"}`, log.LevelInfo},
+		{http.MethodPut, `{"level":"debug"}`, http.StatusOK, `{"level":"DEBUG"}`, log.LevelDebug},
+		{http.MethodPut, `{"level":"verbose"}`, http.StatusBadRequest, `"error"`, log.LevelDebug},
+		{http.MethodPost, `{"level":"warn"}`, http.StatusMethodNotAllowed, `"error"`, log.LevelDebug},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		al.ServeHTTP(rec, httptest.NewRequest(tt.method, "/log/level", strings.NewReader(tt.body)))
+		if rec.Code != tt.code || !strings.Contains(rec.Body.String(), tt.want) {
+			t.Errorf("%s %s: got %d %s, want %d %s", tt.method, tt.body, rec.Code, rec.Body.String(), tt.code, tt.want)
+		}
+		if al.Level() != tt.level {
+			t.Errorf("%s %s: level %v, want %v", tt.method, tt.body, al.Level(), tt.level)
+		}
+	}
+}
+
+// testSource is a config source pushing the JSON documents sent to it.
+type testSource chan string
+
+func (s testSource) Load() ([]*config.KeyValue, error) {
+	return []*config.KeyValue{{Key: "log", Value: []byte(`{"log":{"level":"INFO"}}`), Format: "json"}}, nil
+}
+
+func (s testSource) Watch() (config.Watcher, error) { return s, nil }
+
+func (s testSource) Next() ([]*config.KeyValue, error) {
+	v, ok := <-s
+	if !ok {
+		return nil, context.Canceled
+	}
+	return []*config.KeyValue{{Key: "log", Value: []byte(v), Format: "json"}}, nil
+}
+
+func (s testSource) Stop() error { return nil }
+
+// chanLogger sends every entry to the channel.
+type chanLogger chan string
+
+func (l chanLogger) Log(level log.Level, keyvals ...interface{}) error {
+	l <- fmt.Sprint(keyvals...)
+	return nil
+}
+
+func TestWatchLevel(t *testing.T) {
+	src := make(testSource)
+	c := config.New(config.WithSource(src))
+	defer c.Close()
+	if err := c.Load(); err != nil {
+		t.Fatal(err)
+	}
+	lx := NewConfLogger(&LogxConf{Level: LogLevel_INFO})
+	defer lx.Close()
+	if err := WatchLevel(c, "log.level", lx.Level()); err != nil {
+		t.Fatal(err)
+	}
+	errs := make(chanLogger, 8)
+	defer SetDefault(NewHelper(errs))()
+
+	waitLevel := func(want log.Level) {
+		t.Helper()
+		for deadline := time.Now().Add(time.Second); lx.GetLevel() != want; time.Sleep(time.Millisecond) {
+			if time.Now().After(deadline) {
+				t.Fatalf("level %v, want %v", lx.GetLevel(), want)
+			}
+		}
+	}
+	src <- `{"log":{"level":"WARN"}}`
+	waitLevel(log.LevelWarn)
+	if lx.Enabled("", log.LevelInfo) {
+		t.Error("conf logger not following the watched level")
+	}
+
+	src <- `{"log":{"level":"verbose"}}`
+	if msg := <-errs; !strings.Contains(msg, "watch level log.level") {
+		t.Errorf("unexpected entry %s", msg)
+	}
+	if lx.GetLevel() != log.LevelWarn {
+		t.Errorf("invalid level applied: %v", lx.GetLevel())
+	}
+
+	src <- `{"log":{"level":"3"}}`
+	waitLevel(log.LevelError)
+}
@@ -0,0 +1,90 @@
+package logx
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+
+	"github.com/go-kratos/kratos/v2"
+)
+
+// AfterStop returns a kratos app option that closes l after the app stops,
+// so that the last entries are flushed and files are closed:
+//
+//	lx := logx.NewConfLogger(c)
+//	app := kratos.New(kratos.Logger(logx.WithService(lx, id, name, version)), logx.AfterStop(lx))
+func AfterStop(l *Logger) kratos.Option {
+	return kratos.AfterStop(func(context.Context) error {
+		return l.Close()
+	})
+}
+
+func isStdStream(w io.Writer) bool {
+	return w == os.Stdout || w == os.Stderr
+}
+
+// syncWriter flushes w if it buffers. Stdout and stderr are not synced, since
+// that fails for terminals and pipes.
+func syncWriter(w io.Writer) error {
+	if isStdStream(w) {
+		return nil
+	}
+	if s, ok := w.(interface{ Sync() error }); ok {
+		return s.Sync()
+	}
+	return nil
+}
+
+// closeWriter flushes and closes w, unless it is stdout or stderr.
+func closeWriter(w io.Writer) error {
+	if isStdStream(w) {
+		return nil
+	}
+	if c, ok := w.(io.Closer); ok {
+		return c.Close()
+	}
+	return syncWriter(w)
+}
+
+func (lx *Logger) watchRotateSignals() {
+	lx.sigCh = make(chan os.Signal, 1)
+	lx.sigDone = make(chan struct{})
+	signal.Notify(lx.sigCh, lx.rotateSignals...)
+	go func() {
+		defer close(lx.sigDone)
+		for range lx.sigCh {
+			if err := lx.Rotate(); err != nil {
+				fmt.Fprintf(os.Stderr, "logx: rotate: %v\n", err)
+			}
+		}
+	}()
+}
+
+func (lx *Logger) stopRotateSignals() {
+	if lx.sigCh == nil {
+		return
+	}
+	signal.Stop(lx.sigCh)
+	close(lx.sigCh)
+	<-lx.sigDone
+	lx.sigCh = nil
+}
+
+// createFile creates name with mode if it does not exist yet.
+func createFile(name string, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_APPEND, mode)
+	if err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	// OpenFile 受 umask 影响, 且不会修改已有文件的权限
+	return os.Chmod(name, mode)
+}
@@ -1,18 +1,26 @@
 package logx
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path"
+	"time"
 
 	"github.com/go-kratos/kratos/v2/log"
+	"github.com/zhaoqiang0201/pkg/clock"
 	lumberjack "gopkg.in/natefinch/lumberjack.v2"
 )
 
 const (
-	encoding_json  = "json"
-	encoding_plain = "plain"
+	encoding_json    = "json"
+	encoding_plain   = "plain"
+	encoding_console = "console"
+	encoding_logfmt  = "logfmt"
+	encoding_gelf    = "gelf"
+	encoding_ecs     = "ecs"
+	encoding_otlp    = "otlp"
 )
 
 type Option func(l *Logger)
@@ -46,18 +54,30 @@ func Encoding(encoding string) Option {
 }
 func Level(level string) Option {
 	return func(l *Logger) {
-		l.level = log.ParseLevel(level)
+		l.level.SetLevel(log.ParseLevel(level))
 	}
 }
 
+// SharedLevel makes the logger use al as its level, replacing the level set
+// by Level, so that it can be changed at runtime via al.
+func SharedLevel(al *AtomicLevel) Option {
+	return func(l *Logger) {
+		l.level = al
+	}
+}
+
+// FilterKey sets the keys whose values are replaced by the EntryptionFn.
+//
+// Deprecated: use Redact.
 func FilterKey(keys ...string) Option {
 	return func(l *Logger) {
-		for _, key := range keys {
-			l.filterKey[key] = struct{}{}
-		}
+		l.filterKey = append(l.filterKey, keys...)
 	}
 }
 
+// EntryptionFn sets the function replacing the values of the FilterKey keys.
+//
+// Deprecated: use Redact with RedactEncryptor.
 func EntryptionFn(fn func(string) string) Option {
 	return func(l *Logger) {
 		l.entryptionFn = fn
@@ -70,10 +90,132 @@ func Filename(filename string) Option {
 	}
 }
 
-type Logger struct {
+// Clock sets the clock the ts field is read from.
+func Clock(c clock.PassiveClock) Option {
+	return func(l *Logger) {
+		l.clock = c
+	}
+}
+
+// TimeFormat sets the format of the ts field, see Timestamp.
+func TimeFormat(format string) Option {
+	return func(l *Logger) {
+		l.timeFormat = format
+	}
+}
+
+// TimeUTC logs the ts field in UTC instead of local time.
+func TimeUTC(utc bool) Option {
+	return func(l *Logger) {
+		l.timeUTC = utc
+	}
+}
+
+// FieldNames sets the names of the timestamp, level and message fields of
+// the json encoding. Empty names keep the default ts, level and message.
+func FieldNames(timestamp, level, message string) Option {
+	return func(l *Logger) {
+		l.fieldNames = [3]string{timestamp, level, message}
+	}
+}
+
+// Output writes logs to w instead of the file or stdout.
+func Output(w io.Writer) Option {
+	return func(l *Logger) {
+		l.output = w
+	}
+}
+
+// Sink adds an output with its own encoding and minimum level. target is
+// "stdout", "stderr" or a file name in Dir, rotated like the main log file.
+// Once a sink is added, the Dir/Filename/Output writer is no longer used.
+func Sink(target, encoding, level string) Option {
+	return func(l *Logger) {
+		l.sinkConfs = append(l.sinkConfs, sinkConf{target: target, encoding: encoding, level: log.ParseLevel(level)})
+	}
+}
+
+// SinkWriter is like Sink, but writes to w.
+func SinkWriter(w io.Writer, encoding, level string) Option {
+	return func(l *Logger) {
+		l.sinkConfs = append(l.sinkConfs, sinkConf{w: w, encoding: encoding, level: log.ParseLevel(level)})
+	}
+}
+
+// Async makes every output of the logger an AsyncWriter created with opts.
+func Async(opts ...AsyncOption) Option {
+	return func(l *Logger) {
+		l.async = true
+		l.asyncOpts = opts
+	}
+}
+
+// RotateBy sets how log files are rotated. With Rotation_hourly or
+// Rotation_daily files are switched at the period boundaries of the
+// RotateLocation time zone instead of at MaxSize.
+func RotateBy(rotation Rotation) Option {
+	return func(l *Logger) {
+		l.rotation = rotation
+	}
+}
+
+// RotateLocation sets the time zone of the time based rotation.
+func RotateLocation(loc *time.Location) Option {
+	return func(l *Logger) {
+		l.rotateLoc = loc
+	}
+}
+
+// Compress sets how rotated log files are compressed.
+func Compress(compression Compression) Option {
+	return func(l *Logger) {
+		l.compression = compression
+	}
+}
+
+// LocalTime makes lumberjack name the backup files with local time instead
+// of UTC.
+func LocalTime(localTime bool) Option {
+	return func(l *Logger) {
+		l.localTime = localTime
+	}
+}
+
+// FileMode sets the permissions of the log files, 0600 for size rotated and
+// 0644 for time rotated files if not set.
+func FileMode(mode os.FileMode) Option {
+	return func(l *Logger) {
+		l.fileMode = mode
+	}
+}
+
+// RotateOnSignal rotates the log files when one of sigs is received, e.g.
+// syscall.SIGHUP sent by logrotate's postrotate script.
+func RotateOnSignal(sigs ...os.Signal) Option {
+	return func(l *Logger) {
+		l.rotateSignals = sigs
+	}
+}
+
+type sinkConf struct {
+	target   string
+	w        io.Writer
+	encoding string
+	level    log.Level
+}
+
+// sink 为一个日志输出, 低于 level 的日志不写入
+type sink struct {
 	logger log.Logger
-	// 日志格式字段加密
-	filterKey    map[interface{}]struct{}
+	level  log.Level
+	w      io.Writer
+}
+
+type Logger struct {
+	sinks []sink
+	// 字段脱敏
+	redactor     *Redactor
+	filterKey    []string
 	entryptionFn func(string) string
 
 	dir        string
@@ -82,64 +224,284 @@ type Logger struct {
 	keepDay    int
 	maxBackups int
 	encoding   string
-	level      log.Level
+	level      *AtomicLevel
+	modules    *moduleLevels
+
+	clock      clock.PassiveClock
+	timeFormat string
+	timeUTC    bool
+	fieldNames [3]string
+	output     io.Writer
+	sinkConfs  []sinkConf
+	async      bool
+	asyncOpts  []AsyncOption
+
+	rotation    Rotation
+	rotateLoc   *time.Location
+	compression Compression
+	localTime   bool
+	fileMode    os.FileMode
+
+	errorStack bool
+	stackLevel log.Level
+
+	sampler      *Sampler
+	sampleReport time.Duration
+	sampleStop   chan struct{}
+	sampleDone   chan struct{}
+
+	rotators      []rotator
+	rotateSignals []os.Signal
+	sigCh         chan os.Signal
+	sigDone       chan struct{}
+}
+
+type rotator interface {
+	Rotate() error
+}
+
+type rotateFunc func() error
+
+func (f rotateFunc) Rotate() error {
+	return f()
 }
 
 func NewLogger(opt ...Option) *Logger {
-	var (
-		w io.Writer
-		//accessWriter  io.Writer
-	)
 	logger := &Logger{
-		filterKey: make(map[interface{}]struct{}),
+		level: NewAtomicLevel(log.LevelInfo),
+		clock: clock.RealClock{},
 	}
 
 	for _, o := range opt {
 		o(logger)
 	}
+	if len(logger.filterKey) > 0 && logger.entryptionFn != nil {
+		logger.redactor = logger.redactor.withFunc(logger.filterKey, logger.entryptionFn)
+	}
 
-	switch {
-	case len(logger.dir) > 0:
-		w = &lumberjack.Logger{
-			Filename:   path.Join(logger.dir, logger.filename),
-			MaxSize:    int(logger.maxSize),
-			MaxAge:     int(logger.keepDay),
-			MaxBackups: int(logger.maxBackups),
-			Compress:   false,
+	if len(logger.sinkConfs) == 0 {
+		var w io.Writer
+		switch {
+		case logger.output != nil:
+			w = logger.output
+		case len(logger.dir) > 0:
+			w = logger.newFileWriter(logger.filename)
+		default:
+			w = os.Stdout
 		}
-	case len(logger.dir) == 0:
-		w = os.Stdout
-	default:
-		w = os.Stdout
+		logger.sinks = []sink{logger.newSink(logger.encoding, w, log.LevelDebug)}
 	}
 
-	switch logger.encoding {
+	for _, sc := range logger.sinkConfs {
+		w := sc.w
+		if w == nil {
+			switch sc.target {
+			case "", "stdout":
+				w = os.Stdout
+			case "stderr":
+				w = os.Stderr
+			default:
+				w = logger.newFileWriter(sc.target)
+			}
+		}
+		logger.sinks = append(logger.sinks, logger.newSink(sc.encoding, w, sc.level))
+	}
+
+	if len(logger.rotateSignals) > 0 && len(logger.rotators) > 0 {
+		logger.watchRotateSignals()
+	}
+	if logger.sampler != nil && logger.sampler.clock == nil {
+		logger.sampler.clock = tickerClock(logger.clock)
+	}
+	if logger.sampler != nil && logger.sampleReport > 0 {
+		logger.reportSampling()
+	}
+	return logger
+}
+
+// tickerClock returns c if it can also create tickers, the real clock
+// otherwise.
+func tickerClock(c clock.PassiveClock) clock.Clock {
+	if tc, ok := c.(clock.Clock); ok {
+		return tc
+	}
+	return clock.RealClock{}
+}
+
+func (lx *Logger) newSink(encoding string, w io.Writer, level log.Level) sink {
+	if lx.async {
+		w = NewAsyncWriter(w, lx.asyncOpts...)
+	}
+	return sink{logger: lx.newEncoder(encoding, w, level), level: level, w: w}
+}
+
+func (lx *Logger) newFileWriter(filename string) io.Writer {
+	if lx.rotation != Rotation_size {
+		c := lx.clock
+		if c == nil {
+			c = clock.RealClock{}
+		}
+		w := NewTimeRotateWriter(lx.dir, filename, lx.rotation, lx.rotateLoc, lx.keepDay, lx.maxBackups, lx.compression, c)
+		w.mode = lx.fileMode
+		lx.rotators = append(lx.rotators, w)
+		return w
+	}
+
+	filename = path.Join(lx.dir, filename)
+	if lx.fileMode != 0 {
+		// lumberjack 新建文件权限为 0600, 轮转时沿用已有文件的权限, 因此预先创建文件
+		if err := createFile(filename, lx.fileMode); err != nil {
+			fmt.Fprintf(os.Stderr, "logx: create %s: %v\n", filename, err)
+		}
+	}
+	w := &lumberjack.Logger{
+		Filename:   filename,
+		MaxSize:    int(lx.maxSize),
+		MaxAge:     int(lx.keepDay),
+		MaxBackups: int(lx.maxBackups),
+		LocalTime:  lx.localTime,
+		// lumberjack 只支持 gzip
+		Compress: lx.compression != Compression_none,
+	}
+	mode := lx.fileMode
+	lx.rotators = append(lx.rotators, rotateFunc(func() error {
+		if err := w.Rotate(); err != nil || mode == 0 {
+			return err
+		}
+		// 原文件被 logrotate 移走时 lumberjack 以 0600 新建文件
+		return os.Chmod(filename, mode)
+	}))
+	return w
+}
+
+// Rotate closes the current log files and opens new ones, see RotateOnSignal.
+func (lx *Logger) Rotate() error {
+	var errs []error
+	for _, r := range lx.rotators {
+		if err := r.Rotate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// newEncoder returns the encoder of a sink logging level and above. The json
+// encoder gets level as its zerolog level; the global and module levels
+// stay in Logger, since they can change at runtime.
+func (lx *Logger) newEncoder(encoding string, w io.Writer, level log.Level) log.Logger {
+	switch encoding {
 	case encoding_json:
-		logger.logger = NewZeroLoggerx(w)
+		return NewZeroLoggerx(w, ZeroLevel(level), ZeroFieldNames(lx.fieldNames[0], lx.fieldNames[1], lx.fieldNames[2]))
 	case encoding_plain:
-		logger.logger = newPlainLogger(w)
+		return newPlainLogger(w)
+	case encoding_console:
+		return NewConsoleLogger(w)
+	case encoding_logfmt:
+		return NewLogfmtLogger(w)
+	case encoding_gelf:
+		return NewGELFLogger(w)
+	case encoding_ecs:
+		return NewECSLogger(w)
+	case encoding_otlp:
+		return NewOTLPLogger(w)
 	default:
-		logger.logger = newPlainLogger(w)
+		return newPlainLogger(w)
 	}
+}
 
-	return logger
+// Sync flushes the buffered entries of all outputs of lx.
+func (lx *Logger) Sync() error {
+	var errs []error
+	for _, s := range lx.sinks {
+		if err := syncWriter(s.w); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Dropped returns the number of entries dropped by the async outputs of lx,
+// see Async and AsyncWriter.Dropped.
+func (lx *Logger) Dropped() uint64 {
+	var n uint64
+	for _, s := range lx.sinks {
+		if a, ok := s.w.(*AsyncWriter); ok {
+			n += a.Dropped()
+		}
+	}
+	return n
+}
+
+// Close flushes and closes all outputs of lx, except stdout and stderr.
+// Writers given with Output or SinkWriter are closed too if they are an
+// io.Closer. lx must not be used after Close.
+func (lx *Logger) Close() error {
+	lx.stopRotateSignals()
+	lx.stopSampling()
+	var errs []error
+	for _, s := range lx.sinks {
+		if err := closeWriter(s.w); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Timestamp returns the valuer of the ts field configured for lx.
+func (lx *Logger) Timestamp() log.Valuer {
+	return Timestamp(lx.clock, lx.timeFormat, lx.timeUTC)
+}
+
+// Level returns the level of lx, to be changed at runtime, e.g. by
+// WatchLevel or its ServeHTTP handler.
+func (lx *Logger) Level() *AtomicLevel {
+	return lx.level
+}
+
+// GetLevel returns the current minimum level of lx.
+func (lx *Logger) GetLevel() log.Level {
+	return lx.level.Level()
+}
+
+// SetLevel changes the minimum level of lx at runtime.
+func (lx *Logger) SetLevel(level log.Level) {
+	lx.level.SetLevel(level)
+}
+
+// Enabled reports whether lx logs level for the named logger name, checking
+// the ModuleLevels before the global level.
+func (lx *Logger) Enabled(name string, level log.Level) bool {
+	if ml, ok := lx.modules.lookup(name); ok {
+		return level >= ml
+	}
+	return lx.level.Enabled(level)
 }
 
 func (lx *Logger) Log(level log.Level, keyvals ...interface{}) error {
-	if lx.level > level {
+	name, keyvals := loggerName(keyvals)
+	if !lx.Enabled(name, level) {
 		return nil
 	}
-	var ll log.Logger = lx.logger
-	if len(lx.filterKey) > 0 && lx.entryptionFn != nil {
-		for i := 0; i < len(keyvals); i += 2 {
-			v := i + 1
-			if v >= len(keyvals) {
-				continue
-			}
-			if _, ok := lx.filterKey[keyvals[i]]; ok {
-				keyvals[v] = lx.entryptionFn(fmt.Sprintf("%v", keyvals[v]))
-			}
+	if lx.sampler != nil && !lx.sampler.Allow(level, keyvals...) {
+		return nil
+	}
+	keyvals = lx.redactor.Redact(keyvals)
+	if lx.errorStack && level >= lx.stackLevel {
+		keyvals = append(keyvals[:len(keyvals):len(keyvals)], "stack", loggingStack())
+	}
+	return lx.write(level, keyvals...)
+}
+
+// write writes keyvals to the sinks enabled for level.
+func (lx *Logger) write(level log.Level, keyvals ...interface{}) error {
+	var err error
+	for _, s := range lx.sinks {
+		if level < s.level {
+			continue
+		}
+		if e := s.logger.Log(level, keyvals...); e != nil && err == nil {
+			err = e
 		}
 	}
-	return ll.Log(level, keyvals...)
+	return err
 }
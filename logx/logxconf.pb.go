@@ -20,11 +20,279 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type KeyMatch int32
+
+const (
+	KeyMatch_exact KeyMatch = 0
+	// path.Match 模式, 例如 *_token
+	KeyMatch_glob  KeyMatch = 1
+	KeyMatch_regex KeyMatch = 2
+)
+
+// Enum value maps for KeyMatch.
+var (
+	KeyMatch_name = map[int32]string{
+		0: "exact",
+		1: "glob",
+		2: "regex",
+	}
+	KeyMatch_value = map[string]int32{
+		"exact": 0,
+		"glob":  1,
+		"regex": 2,
+	}
+)
+
+func (x KeyMatch) Enum() *KeyMatch {
+	p := new(KeyMatch)
+	*p = x
+	return p
+}
+
+func (x KeyMatch) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (KeyMatch) Descriptor() protoreflect.EnumDescriptor {
+	return file_logxconf_proto_enumTypes[0].Descriptor()
+}
+
+func (KeyMatch) Type() protoreflect.EnumType {
+	return &file_logxconf_proto_enumTypes[0]
+}
+
+func (x KeyMatch) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use KeyMatch.Descriptor instead.
+func (KeyMatch) EnumDescriptor() ([]byte, []int) {
+	return file_logxconf_proto_rawDescGZIP(), []int{0}
+}
+
+type RedactStrategy int32
+
+const (
+	// 替换为 ******, 内置模式保留部分内容, 例如 138****5678
+	RedactStrategy_mask RedactStrategy = 0
+	// 加盐 HMAC-SHA256, 相同的值得到相同的结果, 便于关联
+	RedactStrategy_hash RedactStrategy = 1
+	// 删除整个字段
+	RedactStrategy_drop RedactStrategy = 2
+	// AES-GCM 加密, 输出 enc:<密钥 id>:<密文>, 使用 logx decrypt 解密
+	RedactStrategy_encrypt RedactStrategy = 3
+)
+
+// Enum value maps for RedactStrategy.
+var (
+	RedactStrategy_name = map[int32]string{
+		0: "mask",
+		1: "hash",
+		2: "drop",
+		3: "encrypt",
+	}
+	RedactStrategy_value = map[string]int32{
+		"mask":    0,
+		"hash":    1,
+		"drop":    2,
+		"encrypt": 3,
+	}
+)
+
+func (x RedactStrategy) Enum() *RedactStrategy {
+	p := new(RedactStrategy)
+	*p = x
+	return p
+}
+
+func (x RedactStrategy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RedactStrategy) Descriptor() protoreflect.EnumDescriptor {
+	return file_logxconf_proto_enumTypes[1].Descriptor()
+}
+
+func (RedactStrategy) Type() protoreflect.EnumType {
+	return &file_logxconf_proto_enumTypes[1]
+}
+
+func (x RedactStrategy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RedactStrategy.Descriptor instead.
+func (RedactStrategy) EnumDescriptor() ([]byte, []int) {
+	return file_logxconf_proto_rawDescGZIP(), []int{1}
+}
+
+// 缓冲区满时: block 阻塞等待, drop_newest 丢弃新日志,
+// drop_debug_first 优先丢弃缓冲区中的 DEBUG 日志, 没有时阻塞等待
+type DropPolicy int32
+
+const (
+	DropPolicy_block            DropPolicy = 0
+	DropPolicy_drop_newest      DropPolicy = 1
+	DropPolicy_drop_debug_first DropPolicy = 2
+)
+
+// Enum value maps for DropPolicy.
+var (
+	DropPolicy_name = map[int32]string{
+		0: "block",
+		1: "drop_newest",
+		2: "drop_debug_first",
+	}
+	DropPolicy_value = map[string]int32{
+		"block":            0,
+		"drop_newest":      1,
+		"drop_debug_first": 2,
+	}
+)
+
+func (x DropPolicy) Enum() *DropPolicy {
+	p := new(DropPolicy)
+	*p = x
+	return p
+}
+
+func (x DropPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DropPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_logxconf_proto_enumTypes[2].Descriptor()
+}
+
+func (DropPolicy) Type() protoreflect.EnumType {
+	return &file_logxconf_proto_enumTypes[2]
+}
+
+func (x DropPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DropPolicy.Descriptor instead.
+func (DropPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_logxconf_proto_rawDescGZIP(), []int{2}
+}
+
+type Rotation int32
+
+const (
+	// 按 max_size 轮转
+	Rotation_size Rotation = 0
+	// 每小时一个文件, 例如 service-2026-10-18-15.log
+	Rotation_hourly Rotation = 1
+	// 每天一个文件, 例如 service-2026-10-18.log
+	Rotation_daily Rotation = 2
+)
+
+// Enum value maps for Rotation.
+var (
+	Rotation_name = map[int32]string{
+		0: "size",
+		1: "hourly",
+		2: "daily",
+	}
+	Rotation_value = map[string]int32{
+		"size":   0,
+		"hourly": 1,
+		"daily":  2,
+	}
+)
+
+func (x Rotation) Enum() *Rotation {
+	p := new(Rotation)
+	*p = x
+	return p
+}
+
+func (x Rotation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Rotation) Descriptor() protoreflect.EnumDescriptor {
+	return file_logxconf_proto_enumTypes[3].Descriptor()
+}
+
+func (Rotation) Type() protoreflect.EnumType {
+	return &file_logxconf_proto_enumTypes[3]
+}
+
+func (x Rotation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Rotation.Descriptor instead.
+func (Rotation) EnumDescriptor() ([]byte, []int) {
+	return file_logxconf_proto_rawDescGZIP(), []int{3}
+}
+
+type Compression int32
+
+const (
+	Compression_none Compression = 0
+	Compression_gzip Compression = 1
+	Compression_zstd Compression = 2
+)
+
+// Enum value maps for Compression.
+var (
+	Compression_name = map[int32]string{
+		0: "none",
+		1: "gzip",
+		2: "zstd",
+	}
+	Compression_value = map[string]int32{
+		"none": 0,
+		"gzip": 1,
+		"zstd": 2,
+	}
+)
+
+func (x Compression) Enum() *Compression {
+	p := new(Compression)
+	*p = x
+	return p
+}
+
+func (x Compression) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Compression) Descriptor() protoreflect.EnumDescriptor {
+	return file_logxconf_proto_enumTypes[4].Descriptor()
+}
+
+func (Compression) Type() protoreflect.EnumType {
+	return &file_logxconf_proto_enumTypes[4]
+}
+
+func (x Compression) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Compression.Descriptor instead.
+func (Compression) EnumDescriptor() ([]byte, []int) {
+	return file_logxconf_proto_rawDescGZIP(), []int{4}
+}
+
 type Encode int32
 
 const (
 	Encode_plain Encode = 0
 	Encode_json  Encode = 1
+	// 本地开发使用, 终端中带颜色输出
+	Encode_console Encode = 2
+	// 严格 logfmt, 固定顺序 ts level caller msg
+	Encode_logfmt Encode = 3
+	// Graylog GELF 1.1
+	Encode_gelf Encode = 4
+	// Elastic Common Schema
+	Encode_ecs Encode = 5
+	// OpenTelemetry 日志数据模型, OTLP/JSON 编码
+	Encode_otlp Encode = 6
 )
 
 // Enum value maps for Encode.
@@ -32,10 +300,20 @@ var (
 	Encode_name = map[int32]string{
 		0: "plain",
 		1: "json",
+		2: "console",
+		3: "logfmt",
+		4: "gelf",
+		5: "ecs",
+		6: "otlp",
 	}
 	Encode_value = map[string]int32{
-		"plain": 0,
-		"json":  1,
+		"plain":   0,
+		"json":    1,
+		"console": 2,
+		"logfmt":  3,
+		"gelf":    4,
+		"ecs":     5,
+		"otlp":    6,
 	}
 )
 
@@ -50,11 +328,11 @@ func (x Encode) String() string {
 }
 
 func (Encode) Descriptor() protoreflect.EnumDescriptor {
-	return file_logxconf_proto_enumTypes[0].Descriptor()
+	return file_logxconf_proto_enumTypes[5].Descriptor()
 }
 
 func (Encode) Type() protoreflect.EnumType {
-	return &file_logxconf_proto_enumTypes[0]
+	return &file_logxconf_proto_enumTypes[5]
 }
 
 func (x Encode) Number() protoreflect.EnumNumber {
@@ -63,7 +341,7 @@ func (x Encode) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Encode.Descriptor instead.
 func (Encode) EnumDescriptor() ([]byte, []int) {
-	return file_logxconf_proto_rawDescGZIP(), []int{0}
+	return file_logxconf_proto_rawDescGZIP(), []int{5}
 }
 
 // protobuf 值必须对应，否则解析会报错
@@ -106,11 +384,11 @@ func (x LogLevel) String() string {
 }
 
 func (LogLevel) Descriptor() protoreflect.EnumDescriptor {
-	return file_logxconf_proto_enumTypes[1].Descriptor()
+	return file_logxconf_proto_enumTypes[6].Descriptor()
 }
 
 func (LogLevel) Type() protoreflect.EnumType {
-	return &file_logxconf_proto_enumTypes[1]
+	return &file_logxconf_proto_enumTypes[6]
 }
 
 func (x LogLevel) Number() protoreflect.EnumNumber {
@@ -119,7 +397,7 @@ func (x LogLevel) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use LogLevel.Descriptor instead.
 func (LogLevel) EnumDescriptor() ([]byte, []int) {
-	return file_logxconf_proto_rawDescGZIP(), []int{1}
+	return file_logxconf_proto_rawDescGZIP(), []int{6}
 }
 
 type LogxConf struct {
@@ -134,6 +412,36 @@ type LogxConf struct {
 	KeepDays   int64    `protobuf:"varint,5,opt,name=keep_days,json=keepDays,proto3" json:"keep_days,omitempty"`
 	MaxBackups int64    `protobuf:"varint,6,opt,name=max_backups,json=maxBackups,proto3" json:"max_backups,omitempty"`
 	MaxSize    int64    `protobuf:"varint,7,opt,name=max_size,json=maxSize,proto3" json:"max_size,omitempty"`
+	// ts 字段格式: rfc3339(默认), rfc3339nano, unix_milli 或自定义 Go time layout
+	TimeFormat string `protobuf:"bytes,8,opt,name=time_format,json=timeFormat,proto3" json:"time_format,omitempty"`
+	// ts 字段使用 UTC 时间, 默认本地时间
+	TimeUtc bool `protobuf:"varint,9,opt,name=time_utc,json=timeUtc,proto3" json:"time_utc,omitempty"`
+	// 按 logger 名称设置日志级别, key 支持 glob, 例如 "db.*"
+	ModuleLevels map[string]LogLevel `protobuf:"bytes,10,rep,name=module_levels,json=moduleLevels,proto3" json:"module_levels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3,enum=logx.LogLevel"`
+	// 多个输出, 设置后不再使用 path_dir/file_name 的默认输出
+	Sinks []*LogxSink `protobuf:"bytes,11,rep,name=sinks,proto3" json:"sinks,omitempty"`
+	// 异步写入, 为空时同步写
+	Async *LogxAsync `protobuf:"bytes,12,opt,name=async,proto3" json:"async,omitempty"`
+	// 按时间轮转, 默认按 max_size 轮转
+	Rotation Rotation `protobuf:"varint,13,opt,name=rotation,proto3,enum=logx.Rotation" json:"rotation,omitempty"`
+	// 按时间轮转的时区, 例如 Asia/Shanghai, 默认本地时区
+	RotationTimezone string `protobuf:"bytes,14,opt,name=rotation_timezone,json=rotationTimezone,proto3" json:"rotation_timezone,omitempty"`
+	// 轮转后的文件压缩方式, 按 max_size 轮转时只支持 gzip, zstd 按 gzip 处理
+	Compression Compression `protobuf:"varint,15,opt,name=compression,proto3,enum=logx.Compression" json:"compression,omitempty"`
+	// 按 max_size 轮转时备份文件名使用本地时间, 默认 UTC
+	LocalTime bool `protobuf:"varint,16,opt,name=local_time,json=localTime,proto3" json:"local_time,omitempty"`
+	// 日志文件权限, 八进制字符串, 例如 "0640"
+	FileMode string `protobuf:"bytes,17,opt,name=file_mode,json=fileMode,proto3" json:"file_mode,omitempty"`
+	// 收到 SIGHUP 时重新打开日志文件, 用于配合 logrotate
+	RotateOnSighup bool `protobuf:"varint,18,opt,name=rotate_on_sighup,json=rotateOnSighup,proto3" json:"rotate_on_sighup,omitempty"`
+	// 日志采样, 为空时不采样
+	Sampling *LogxSampling `protobuf:"bytes,19,opt,name=sampling,proto3" json:"sampling,omitempty"`
+	// 字段脱敏, 为空时不脱敏
+	Redact *LogxRedact `protobuf:"bytes,20,opt,name=redact,proto3" json:"redact,omitempty"`
+	// json 格式的字段名, 为空时使用默认值
+	FieldNames *LogxFieldNames `protobuf:"bytes,21,opt,name=field_names,json=fieldNames,proto3" json:"field_names,omitempty"`
+	// ERROR 及以上级别的日志附带 goroutine 堆栈, 字段名 stack
+	ErrorStack bool `protobuf:"varint,22,opt,name=error_stack,json=errorStack,proto3" json:"error_stack,omitempty"`
 }
 
 func (x *LogxConf) Reset() {
@@ -217,74 +525,812 @@ func (x *LogxConf) GetMaxSize() int64 {
 	return 0
 }
 
-var File_logxconf_proto protoreflect.FileDescriptor
+func (x *LogxConf) GetTimeFormat() string {
+	if x != nil {
+		return x.TimeFormat
+	}
+	return ""
+}
 
-var file_logxconf_proto_rawDesc = []byte{
-	0x0a, 0x0e, 0x6c, 0x6f, 0x67, 0x78, 0x63, 0x6f, 0x6e, 0x66, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x12, 0x04, 0x6c, 0x6f, 0x67, 0x78, 0x22, 0xeb, 0x01, 0x0a, 0x08, 0x4c, 0x6f, 0x67, 0x78, 0x43,
-	0x6f, 0x6e, 0x66, 0x12, 0x19, 0x0a, 0x08, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x64, 0x69, 0x72, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x74, 0x68, 0x44, 0x69, 0x72, 0x12, 0x1b,
-	0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x28, 0x0a, 0x08, 0x65,
-	0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e,
-	0x6c, 0x6f, 0x67, 0x78, 0x2e, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x52, 0x08, 0x65, 0x6e, 0x63,
-	0x6f, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x24, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x6c, 0x6f, 0x67, 0x78, 0x2e, 0x4c, 0x6f, 0x67, 0x4c,
-	0x65, 0x76, 0x65, 0x6c, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x6b,
-	0x65, 0x65, 0x70, 0x5f, 0x64, 0x61, 0x79, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08,
-	0x6b, 0x65, 0x65, 0x70, 0x44, 0x61, 0x79, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x5f,
-	0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6d,
-	0x61, 0x78, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x78,
-	0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6d, 0x61, 0x78,
-	0x53, 0x69, 0x7a, 0x65, 0x2a, 0x1d, 0x0a, 0x06, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x09,
-	0x0a, 0x05, 0x70, 0x6c, 0x61, 0x69, 0x6e, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x6a, 0x73, 0x6f,
-	0x6e, 0x10, 0x01, 0x2a, 0x3f, 0x0a, 0x08, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12,
-	0x09, 0x0a, 0x05, 0x44, 0x45, 0x42, 0x55, 0x47, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x49, 0x4e,
-	0x46, 0x4f, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x57, 0x41, 0x52, 0x4e, 0x10, 0x02, 0x12, 0x09,
-	0x0a, 0x05, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x03, 0x12, 0x09, 0x0a, 0x05, 0x46, 0x41, 0x54,
-	0x41, 0x4c, 0x10, 0x04, 0x42, 0x23, 0x5a, 0x21, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
-	0x6f, 0x6d, 0x2f, 0x7a, 0x68, 0x61, 0x6f, 0x67, 0x6f, 0x67, 0x6f, 0x2f, 0x70, 0x6b, 0x67, 0x2f,
-	0x6c, 0x6f, 0x67, 0x78, 0x3b, 0x6c, 0x6f, 0x67, 0x78, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+func (x *LogxConf) GetTimeUtc() bool {
+	if x != nil {
+		return x.TimeUtc
+	}
+	return false
 }
 
-var (
-	file_logxconf_proto_rawDescOnce sync.Once
-	file_logxconf_proto_rawDescData = file_logxconf_proto_rawDesc
-)
+func (x *LogxConf) GetModuleLevels() map[string]LogLevel {
+	if x != nil {
+		return x.ModuleLevels
+	}
+	return nil
+}
 
-func file_logxconf_proto_rawDescGZIP() []byte {
-	file_logxconf_proto_rawDescOnce.Do(func() {
-		file_logxconf_proto_rawDescData = protoimpl.X.CompressGZIP(file_logxconf_proto_rawDescData)
-	})
-	return file_logxconf_proto_rawDescData
+func (x *LogxConf) GetSinks() []*LogxSink {
+	if x != nil {
+		return x.Sinks
+	}
+	return nil
 }
 
-var file_logxconf_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_logxconf_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
-var file_logxconf_proto_goTypes = []interface{}{
-	(Encode)(0),      // 0: logx.Encode
-	(LogLevel)(0),    // 1: logx.LogLevel
-	(*LogxConf)(nil), // 2: logx.LogxConf
+func (x *LogxConf) GetAsync() *LogxAsync {
+	if x != nil {
+		return x.Async
+	}
+	return nil
 }
-var file_logxconf_proto_depIdxs = []int32{
-	0, // 0: logx.LogxConf.encoding:type_name -> logx.Encode
-	1, // 1: logx.LogxConf.level:type_name -> logx.LogLevel
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+
+func (x *LogxConf) GetRotation() Rotation {
+	if x != nil {
+		return x.Rotation
+	}
+	return Rotation_size
 }
 
-func init() { file_logxconf_proto_init() }
-func file_logxconf_proto_init() {
-	if File_logxconf_proto != nil {
-		return
+func (x *LogxConf) GetRotationTimezone() string {
+	if x != nil {
+		return x.RotationTimezone
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_logxconf_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LogxConf); i {
+	return ""
+}
+
+func (x *LogxConf) GetCompression() Compression {
+	if x != nil {
+		return x.Compression
+	}
+	return Compression_none
+}
+
+func (x *LogxConf) GetLocalTime() bool {
+	if x != nil {
+		return x.LocalTime
+	}
+	return false
+}
+
+func (x *LogxConf) GetFileMode() string {
+	if x != nil {
+		return x.FileMode
+	}
+	return ""
+}
+
+func (x *LogxConf) GetRotateOnSighup() bool {
+	if x != nil {
+		return x.RotateOnSighup
+	}
+	return false
+}
+
+func (x *LogxConf) GetSampling() *LogxSampling {
+	if x != nil {
+		return x.Sampling
+	}
+	return nil
+}
+
+func (x *LogxConf) GetRedact() *LogxRedact {
+	if x != nil {
+		return x.Redact
+	}
+	return nil
+}
+
+func (x *LogxConf) GetFieldNames() *LogxFieldNames {
+	if x != nil {
+		return x.FieldNames
+	}
+	return nil
+}
+
+func (x *LogxConf) GetErrorStack() bool {
+	if x != nil {
+		return x.ErrorStack
+	}
+	return false
+}
+
+type LogxFieldNames struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// 默认 ts
+	Timestamp string `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// 默认 level
+	Level string `protobuf:"bytes,2,opt,name=level,proto3" json:"level,omitempty"`
+	// 默认 message
+	Message string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *LogxFieldNames) Reset() {
+	*x = LogxFieldNames{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logxconf_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LogxFieldNames) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogxFieldNames) ProtoMessage() {}
+
+func (x *LogxFieldNames) ProtoReflect() protoreflect.Message {
+	mi := &file_logxconf_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogxFieldNames.ProtoReflect.Descriptor instead.
+func (*LogxFieldNames) Descriptor() ([]byte, []int) {
+	return file_logxconf_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *LogxFieldNames) GetTimestamp() string {
+	if x != nil {
+		return x.Timestamp
+	}
+	return ""
+}
+
+func (x *LogxFieldNames) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *LogxFieldNames) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type LogxRedact struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rules []*LogxRedactRule `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	// hash 策略的 HMAC 密钥
+	HashSalt string `protobuf:"bytes,2,opt,name=hash_salt,json=hashSalt,proto3" json:"hash_salt,omitempty"`
+	// encrypt 策略的 AES 密钥, base64 编码, 16, 24 或 32 字节
+	AesKey string `protobuf:"bytes,3,opt,name=aes_key,json=aesKey,proto3" json:"aes_key,omitempty"`
+	// encrypt 策略的密钥文件, 可包含多个密钥用于轮换, 格式见 LoadKeyring, 优先于 aes_key
+	AesKeyFile string `protobuf:"bytes,4,opt,name=aes_key_file,json=aesKeyFile,proto3" json:"aes_key_file,omitempty"`
+}
+
+func (x *LogxRedact) Reset() {
+	*x = LogxRedact{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logxconf_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LogxRedact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogxRedact) ProtoMessage() {}
+
+func (x *LogxRedact) ProtoReflect() protoreflect.Message {
+	mi := &file_logxconf_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogxRedact.ProtoReflect.Descriptor instead.
+func (*LogxRedact) Descriptor() ([]byte, []int) {
+	return file_logxconf_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *LogxRedact) GetRules() []*LogxRedactRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *LogxRedact) GetHashSalt() string {
+	if x != nil {
+		return x.HashSalt
+	}
+	return ""
+}
+
+func (x *LogxRedact) GetAesKey() string {
+	if x != nil {
+		return x.AesKey
+	}
+	return ""
+}
+
+func (x *LogxRedact) GetAesKeyFile() string {
+	if x != nil {
+		return x.AesKeyFile
+	}
+	return ""
+}
+
+// 字段名匹配 key 时脱敏整个值; 设置 value 时只脱敏值中匹配的部分, key 为空表示所有字段
+type LogxRedactRule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// 字段名或以点分隔的嵌套路径, 例如 password, user.email
+	Key   string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Match KeyMatch `protobuf:"varint,2,opt,name=match,proto3,enum=logx.KeyMatch" json:"match,omitempty"`
+	// 内置模式 email, phone, card, token, 或正则表达式
+	Value    string         `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	Strategy RedactStrategy `protobuf:"varint,4,opt,name=strategy,proto3,enum=logx.RedactStrategy" json:"strategy,omitempty"`
+}
+
+func (x *LogxRedactRule) Reset() {
+	*x = LogxRedactRule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logxconf_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LogxRedactRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogxRedactRule) ProtoMessage() {}
+
+func (x *LogxRedactRule) ProtoReflect() protoreflect.Message {
+	mi := &file_logxconf_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogxRedactRule.ProtoReflect.Descriptor instead.
+func (*LogxRedactRule) Descriptor() ([]byte, []int) {
+	return file_logxconf_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *LogxRedactRule) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *LogxRedactRule) GetMatch() KeyMatch {
+	if x != nil {
+		return x.Match
+	}
+	return KeyMatch_exact
+}
+
+func (x *LogxRedactRule) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *LogxRedactRule) GetStrategy() RedactStrategy {
+	if x != nil {
+		return x.Strategy
+	}
+	return RedactStrategy_mask
+}
+
+// 每个周期内相同级别和消息的日志, 前 first 条全部输出, 之后每 thereafter 条输出一条
+type LogxSampling struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// 周期(毫秒), 默认 1000
+	TickMs int64 `protobuf:"varint,1,opt,name=tick_ms,json=tickMs,proto3" json:"tick_ms,omitempty"`
+	// first 和 thereafter 都为 0 时默认 100, 100
+	First int64 `protobuf:"varint,2,opt,name=first,proto3" json:"first,omitempty"`
+	// 0 表示周期内剩余的日志全部丢弃
+	Thereafter int64 `protobuf:"varint,3,opt,name=thereafter,proto3" json:"thereafter,omitempty"`
+	// 输出被丢弃条数的间隔(秒), 默认 60
+	ReportIntervalS int64 `protobuf:"varint,4,opt,name=report_interval_s,json=reportIntervalS,proto3" json:"report_interval_s,omitempty"`
+}
+
+func (x *LogxSampling) Reset() {
+	*x = LogxSampling{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logxconf_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LogxSampling) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogxSampling) ProtoMessage() {}
+
+func (x *LogxSampling) ProtoReflect() protoreflect.Message {
+	mi := &file_logxconf_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogxSampling.ProtoReflect.Descriptor instead.
+func (*LogxSampling) Descriptor() ([]byte, []int) {
+	return file_logxconf_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *LogxSampling) GetTickMs() int64 {
+	if x != nil {
+		return x.TickMs
+	}
+	return 0
+}
+
+func (x *LogxSampling) GetFirst() int64 {
+	if x != nil {
+		return x.First
+	}
+	return 0
+}
+
+func (x *LogxSampling) GetThereafter() int64 {
+	if x != nil {
+		return x.Thereafter
+	}
+	return 0
+}
+
+func (x *LogxSampling) GetReportIntervalS() int64 {
+	if x != nil {
+		return x.ReportIntervalS
+	}
+	return 0
+}
+
+type LogxSink struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// stdout, stderr 或 path_dir 下的文件名, 轮转参数与 LogxConf 相同
+	Target   string `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	Encoding Encode `protobuf:"varint,2,opt,name=encoding,proto3,enum=logx.Encode" json:"encoding,omitempty"`
+	// 该输出的最低日志级别
+	Level LogLevel `protobuf:"varint,3,opt,name=level,proto3,enum=logx.LogLevel" json:"level,omitempty"`
+}
+
+func (x *LogxSink) Reset() {
+	*x = LogxSink{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logxconf_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LogxSink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogxSink) ProtoMessage() {}
+
+func (x *LogxSink) ProtoReflect() protoreflect.Message {
+	mi := &file_logxconf_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogxSink.ProtoReflect.Descriptor instead.
+func (*LogxSink) Descriptor() ([]byte, []int) {
+	return file_logxconf_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *LogxSink) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *LogxSink) GetEncoding() Encode {
+	if x != nil {
+		return x.Encoding
+	}
+	return Encode_plain
+}
+
+func (x *LogxSink) GetLevel() LogLevel {
+	if x != nil {
+		return x.Level
+	}
+	return LogLevel_DEBUG
+}
+
+type LogxAsync struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// 缓冲区可容纳的日志条数, 默认 4096
+	BufferSize int64 `protobuf:"varint,1,opt,name=buffer_size,json=bufferSize,proto3" json:"buffer_size,omitempty"`
+	// 缓冲区满时的处理方式
+	DropPolicy DropPolicy `protobuf:"varint,2,opt,name=drop_policy,json=dropPolicy,proto3,enum=logx.DropPolicy" json:"drop_policy,omitempty"`
+	// 定期刷新间隔(毫秒), 默认 1000
+	FlushIntervalMs int64 `protobuf:"varint,3,opt,name=flush_interval_ms,json=flushIntervalMs,proto3" json:"flush_interval_ms,omitempty"`
+}
+
+func (x *LogxAsync) Reset() {
+	*x = LogxAsync{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logxconf_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LogxAsync) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogxAsync) ProtoMessage() {}
+
+func (x *LogxAsync) ProtoReflect() protoreflect.Message {
+	mi := &file_logxconf_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogxAsync.ProtoReflect.Descriptor instead.
+func (*LogxAsync) Descriptor() ([]byte, []int) {
+	return file_logxconf_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *LogxAsync) GetBufferSize() int64 {
+	if x != nil {
+		return x.BufferSize
+	}
+	return 0
+}
+
+func (x *LogxAsync) GetDropPolicy() DropPolicy {
+	if x != nil {
+		return x.DropPolicy
+	}
+	return DropPolicy_block
+}
+
+func (x *LogxAsync) GetFlushIntervalMs() int64 {
+	if x != nil {
+		return x.FlushIntervalMs
+	}
+	return 0
+}
+
+var File_logxconf_proto protoreflect.FileDescriptor
+
+var file_logxconf_proto_rawDesc = []byte{
+	0x0a, 0x0e, 0x6c, 0x6f, 0x67, 0x78, 0x63, 0x6f, 0x6e, 0x66, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x04, 0x6c, 0x6f, 0x67, 0x78, 0x22, 0xb2, 0x07, 0x0a, 0x08, 0x4c, 0x6f, 0x67, 0x78, 0x43,
+	0x6f, 0x6e, 0x66, 0x12, 0x19, 0x0a, 0x08, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x64, 0x69, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x74, 0x68, 0x44, 0x69, 0x72, 0x12, 0x1b,
+	0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x28, 0x0a, 0x08, 0x65,
+	0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e,
+	0x6c, 0x6f, 0x67, 0x78, 0x2e, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x52, 0x08, 0x65, 0x6e, 0x63,
+	0x6f, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x24, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x6c, 0x6f, 0x67, 0x78, 0x2e, 0x4c, 0x6f, 0x67, 0x4c,
+	0x65, 0x76, 0x65, 0x6c, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x6b,
+	0x65, 0x65, 0x70, 0x5f, 0x64, 0x61, 0x79, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08,
+	0x6b, 0x65, 0x65, 0x70, 0x44, 0x61, 0x79, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x5f,
+	0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6d,
+	0x61, 0x78, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x78,
+	0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6d, 0x61, 0x78,
+	0x53, 0x69, 0x7a, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x66, 0x6f, 0x72,
+	0x6d, 0x61, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x46,
+	0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x75, 0x74,
+	0x63, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x55, 0x74, 0x63,
+	0x12, 0x45, 0x0a, 0x0d, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c,
+	0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6c, 0x6f, 0x67, 0x78, 0x2e, 0x4c,
+	0x6f, 0x67, 0x78, 0x43, 0x6f, 0x6e, 0x66, 0x2e, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x4c, 0x65,
+	0x76, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x6d, 0x6f, 0x64, 0x75, 0x6c,
+	0x65, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x73, 0x12, 0x24, 0x0a, 0x05, 0x73, 0x69, 0x6e, 0x6b, 0x73,
+	0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x6c, 0x6f, 0x67, 0x78, 0x2e, 0x4c, 0x6f,
+	0x67, 0x78, 0x53, 0x69, 0x6e, 0x6b, 0x52, 0x05, 0x73, 0x69, 0x6e, 0x6b, 0x73, 0x12, 0x25, 0x0a,
+	0x05, 0x61, 0x73, 0x79, 0x6e, 0x63, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6c,
+	0x6f, 0x67, 0x78, 0x2e, 0x4c, 0x6f, 0x67, 0x78, 0x41, 0x73, 0x79, 0x6e, 0x63, 0x52, 0x05, 0x61,
+	0x73, 0x79, 0x6e, 0x63, 0x12, 0x2a, 0x0a, 0x08, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x0d, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x6c, 0x6f, 0x67, 0x78, 0x2e, 0x52, 0x6f,
+	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x2b, 0x0a, 0x11, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69, 0x6d,
+	0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x72, 0x6f, 0x74,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x12, 0x33, 0x0a,
+	0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0f, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x11, 0x2e, 0x6c, 0x6f, 0x67, 0x78, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x72, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x10, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x54, 0x69, 0x6d,
+	0x65, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x11,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x28,
+	0x0a, 0x10, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x6f, 0x6e, 0x5f, 0x73, 0x69, 0x67, 0x68,
+	0x75, 0x70, 0x18, 0x12, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x65,
+	0x4f, 0x6e, 0x53, 0x69, 0x67, 0x68, 0x75, 0x70, 0x12, 0x2e, 0x0a, 0x08, 0x73, 0x61, 0x6d, 0x70,
+	0x6c, 0x69, 0x6e, 0x67, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6c, 0x6f, 0x67,
+	0x78, 0x2e, 0x4c, 0x6f, 0x67, 0x78, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x52, 0x08,
+	0x73, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x12, 0x28, 0x0a, 0x06, 0x72, 0x65, 0x64, 0x61,
+	0x63, 0x74, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6c, 0x6f, 0x67, 0x78, 0x2e,
+	0x4c, 0x6f, 0x67, 0x78, 0x52, 0x65, 0x64, 0x61, 0x63, 0x74, 0x52, 0x06, 0x72, 0x65, 0x64, 0x61,
+	0x63, 0x74, 0x12, 0x35, 0x0a, 0x0b, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x73, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6c, 0x6f, 0x67, 0x78, 0x2e, 0x4c,
+	0x6f, 0x67, 0x78, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x52, 0x0a, 0x66,
+	0x69, 0x65, 0x6c, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x5f, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x18, 0x16, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x53, 0x74, 0x61, 0x63, 0x6b, 0x1a, 0x4f, 0x0a, 0x11, 0x4d, 0x6f,
+	0x64, 0x75, 0x6c, 0x65, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x24, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x0e, 0x2e, 0x6c, 0x6f, 0x67, 0x78, 0x2e, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x5e, 0x0a, 0x0e, 0x4c,
+	0x6f, 0x67, 0x78, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x1c, 0x0a,
+	0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x6c,
+	0x65, 0x76, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65,
+	0x6c, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x90, 0x01, 0x0a, 0x0a,
+	0x4c, 0x6f, 0x67, 0x78, 0x52, 0x65, 0x64, 0x61, 0x63, 0x74, 0x12, 0x2a, 0x0a, 0x05, 0x72, 0x75,
+	0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6c, 0x6f, 0x67, 0x78,
+	0x2e, 0x4c, 0x6f, 0x67, 0x78, 0x52, 0x65, 0x64, 0x61, 0x63, 0x74, 0x52, 0x75, 0x6c, 0x65, 0x52,
+	0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x68, 0x61, 0x73, 0x68, 0x5f, 0x73,
+	0x61, 0x6c, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x61, 0x73, 0x68, 0x53,
+	0x61, 0x6c, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x61, 0x65, 0x73, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x65, 0x73, 0x4b, 0x65, 0x79, 0x12, 0x20, 0x0a, 0x0c,
+	0x61, 0x65, 0x73, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x61, 0x65, 0x73, 0x4b, 0x65, 0x79, 0x46, 0x69, 0x6c, 0x65, 0x22, 0x90,
+	0x01, 0x0a, 0x0e, 0x4c, 0x6f, 0x67, 0x78, 0x52, 0x65, 0x64, 0x61, 0x63, 0x74, 0x52, 0x75, 0x6c,
+	0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x24, 0x0a, 0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x6c, 0x6f, 0x67, 0x78, 0x2e, 0x4b, 0x65, 0x79, 0x4d, 0x61, 0x74,
+	0x63, 0x68, 0x52, 0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12,
+	0x30, 0x0a, 0x08, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x14, 0x2e, 0x6c, 0x6f, 0x67, 0x78, 0x2e, 0x52, 0x65, 0x64, 0x61, 0x63, 0x74, 0x53,
+	0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x52, 0x08, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67,
+	0x79, 0x22, 0x89, 0x01, 0x0a, 0x0c, 0x4c, 0x6f, 0x67, 0x78, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69,
+	0x6e, 0x67, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x69, 0x63, 0x6b, 0x5f, 0x6d, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x4d, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x66,
+	0x69, 0x72, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x66, 0x69, 0x72, 0x73,
+	0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x68, 0x65, 0x72, 0x65, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x74, 0x68, 0x65, 0x72, 0x65, 0x61, 0x66, 0x74, 0x65,
+	0x72, 0x12, 0x2a, 0x0a, 0x11, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x76, 0x61, 0x6c, 0x5f, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x72, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x53, 0x22, 0x72, 0x0a,
+	0x08, 0x4c, 0x6f, 0x67, 0x78, 0x53, 0x69, 0x6e, 0x6b, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x12, 0x28, 0x0a, 0x08, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x6c, 0x6f, 0x67, 0x78, 0x2e, 0x45, 0x6e, 0x63, 0x6f, 0x64,
+	0x65, 0x52, 0x08, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x24, 0x0a, 0x05, 0x6c,
+	0x65, 0x76, 0x65, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x6c, 0x6f, 0x67,
+	0x78, 0x2e, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65,
+	0x6c, 0x22, 0x8b, 0x01, 0x0a, 0x09, 0x4c, 0x6f, 0x67, 0x78, 0x41, 0x73, 0x79, 0x6e, 0x63, 0x12,
+	0x1f, 0x0a, 0x0b, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x53, 0x69, 0x7a, 0x65,
+	0x12, 0x31, 0x0a, 0x0b, 0x64, 0x72, 0x6f, 0x70, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x6c, 0x6f, 0x67, 0x78, 0x2e, 0x44, 0x72, 0x6f,
+	0x70, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x0a, 0x64, 0x72, 0x6f, 0x70, 0x50, 0x6f, 0x6c,
+	0x69, 0x63, 0x79, 0x12, 0x2a, 0x0a, 0x11, 0x66, 0x6c, 0x75, 0x73, 0x68, 0x5f, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x76, 0x61, 0x6c, 0x5f, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f,
+	0x66, 0x6c, 0x75, 0x73, 0x68, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x4d, 0x73, 0x2a,
+	0x2a, 0x0a, 0x08, 0x4b, 0x65, 0x79, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x09, 0x0a, 0x05, 0x65,
+	0x78, 0x61, 0x63, 0x74, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x67, 0x6c, 0x6f, 0x62, 0x10, 0x01,
+	0x12, 0x09, 0x0a, 0x05, 0x72, 0x65, 0x67, 0x65, 0x78, 0x10, 0x02, 0x2a, 0x3b, 0x0a, 0x0e, 0x52,
+	0x65, 0x64, 0x61, 0x63, 0x74, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x12, 0x08, 0x0a,
+	0x04, 0x6d, 0x61, 0x73, 0x6b, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x10,
+	0x01, 0x12, 0x08, 0x0a, 0x04, 0x64, 0x72, 0x6f, 0x70, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x65,
+	0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x10, 0x03, 0x2a, 0x3e, 0x0a, 0x0a, 0x44, 0x72, 0x6f, 0x70,
+	0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x09, 0x0a, 0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x10,
+	0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x64, 0x72, 0x6f, 0x70, 0x5f, 0x6e, 0x65, 0x77, 0x65, 0x73, 0x74,
+	0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x64, 0x72, 0x6f, 0x70, 0x5f, 0x64, 0x65, 0x62, 0x75, 0x67,
+	0x5f, 0x66, 0x69, 0x72, 0x73, 0x74, 0x10, 0x02, 0x2a, 0x2b, 0x0a, 0x08, 0x52, 0x6f, 0x74, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x08, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x10, 0x00, 0x12, 0x0a,
+	0x0a, 0x06, 0x68, 0x6f, 0x75, 0x72, 0x6c, 0x79, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x64, 0x61,
+	0x69, 0x6c, 0x79, 0x10, 0x02, 0x2a, 0x2b, 0x0a, 0x0b, 0x43, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x08, 0x0a, 0x04, 0x6e, 0x6f, 0x6e, 0x65, 0x10, 0x00, 0x12, 0x08,
+	0x0a, 0x04, 0x67, 0x7a, 0x69, 0x70, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x7a, 0x73, 0x74, 0x64,
+	0x10, 0x02, 0x2a, 0x53, 0x0a, 0x06, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x09, 0x0a, 0x05,
+	0x70, 0x6c, 0x61, 0x69, 0x6e, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x6a, 0x73, 0x6f, 0x6e, 0x10,
+	0x01, 0x12, 0x0b, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x73, 0x6f, 0x6c, 0x65, 0x10, 0x02, 0x12, 0x0a,
+	0x0a, 0x06, 0x6c, 0x6f, 0x67, 0x66, 0x6d, 0x74, 0x10, 0x03, 0x12, 0x08, 0x0a, 0x04, 0x67, 0x65,
+	0x6c, 0x66, 0x10, 0x04, 0x12, 0x07, 0x0a, 0x03, 0x65, 0x63, 0x73, 0x10, 0x05, 0x12, 0x08, 0x0a,
+	0x04, 0x6f, 0x74, 0x6c, 0x70, 0x10, 0x06, 0x2a, 0x3f, 0x0a, 0x08, 0x4c, 0x6f, 0x67, 0x4c, 0x65,
+	0x76, 0x65, 0x6c, 0x12, 0x09, 0x0a, 0x05, 0x44, 0x45, 0x42, 0x55, 0x47, 0x10, 0x00, 0x12, 0x08,
+	0x0a, 0x04, 0x49, 0x4e, 0x46, 0x4f, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x57, 0x41, 0x52, 0x4e,
+	0x10, 0x02, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x03, 0x12, 0x09, 0x0a,
+	0x05, 0x46, 0x41, 0x54, 0x41, 0x4c, 0x10, 0x04, 0x42, 0x23, 0x5a, 0x21, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x7a, 0x68, 0x61, 0x6f, 0x67, 0x6f, 0x67, 0x6f, 0x2f,
+	0x70, 0x6b, 0x67, 0x2f, 0x6c, 0x6f, 0x67, 0x78, 0x3b, 0x6c, 0x6f, 0x67, 0x78, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_logxconf_proto_rawDescOnce sync.Once
+	file_logxconf_proto_rawDescData = file_logxconf_proto_rawDesc
+)
+
+func file_logxconf_proto_rawDescGZIP() []byte {
+	file_logxconf_proto_rawDescOnce.Do(func() {
+		file_logxconf_proto_rawDescData = protoimpl.X.CompressGZIP(file_logxconf_proto_rawDescData)
+	})
+	return file_logxconf_proto_rawDescData
+}
+
+var file_logxconf_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
+var file_logxconf_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_logxconf_proto_goTypes = []interface{}{
+	(KeyMatch)(0),          // 0: logx.KeyMatch
+	(RedactStrategy)(0),    // 1: logx.RedactStrategy
+	(DropPolicy)(0),        // 2: logx.DropPolicy
+	(Rotation)(0),          // 3: logx.Rotation
+	(Compression)(0),       // 4: logx.Compression
+	(Encode)(0),            // 5: logx.Encode
+	(LogLevel)(0),          // 6: logx.LogLevel
+	(*LogxConf)(nil),       // 7: logx.LogxConf
+	(*LogxFieldNames)(nil), // 8: logx.LogxFieldNames
+	(*LogxRedact)(nil),     // 9: logx.LogxRedact
+	(*LogxRedactRule)(nil), // 10: logx.LogxRedactRule
+	(*LogxSampling)(nil),   // 11: logx.LogxSampling
+	(*LogxSink)(nil),       // 12: logx.LogxSink
+	(*LogxAsync)(nil),      // 13: logx.LogxAsync
+	nil,                    // 14: logx.LogxConf.ModuleLevelsEntry
+}
+var file_logxconf_proto_depIdxs = []int32{
+	5,  // 0: logx.LogxConf.encoding:type_name -> logx.Encode
+	6,  // 1: logx.LogxConf.level:type_name -> logx.LogLevel
+	14, // 2: logx.LogxConf.module_levels:type_name -> logx.LogxConf.ModuleLevelsEntry
+	12, // 3: logx.LogxConf.sinks:type_name -> logx.LogxSink
+	13, // 4: logx.LogxConf.async:type_name -> logx.LogxAsync
+	3,  // 5: logx.LogxConf.rotation:type_name -> logx.Rotation
+	4,  // 6: logx.LogxConf.compression:type_name -> logx.Compression
+	11, // 7: logx.LogxConf.sampling:type_name -> logx.LogxSampling
+	9,  // 8: logx.LogxConf.redact:type_name -> logx.LogxRedact
+	8,  // 9: logx.LogxConf.field_names:type_name -> logx.LogxFieldNames
+	10, // 10: logx.LogxRedact.rules:type_name -> logx.LogxRedactRule
+	0,  // 11: logx.LogxRedactRule.match:type_name -> logx.KeyMatch
+	1,  // 12: logx.LogxRedactRule.strategy:type_name -> logx.RedactStrategy
+	5,  // 13: logx.LogxSink.encoding:type_name -> logx.Encode
+	6,  // 14: logx.LogxSink.level:type_name -> logx.LogLevel
+	2,  // 15: logx.LogxAsync.drop_policy:type_name -> logx.DropPolicy
+	6,  // 16: logx.LogxConf.ModuleLevelsEntry.value:type_name -> logx.LogLevel
+	17, // [17:17] is the sub-list for method output_type
+	17, // [17:17] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
+}
+
+func init() { file_logxconf_proto_init() }
+func file_logxconf_proto_init() {
+	if File_logxconf_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_logxconf_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LogxConf); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logxconf_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LogxFieldNames); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logxconf_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LogxRedact); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logxconf_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LogxRedactRule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logxconf_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LogxSampling); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logxconf_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LogxSink); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logxconf_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LogxAsync); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -301,8 +1347,8 @@ func file_logxconf_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_logxconf_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   1,
+			NumEnums:      7,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
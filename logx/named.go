@@ -0,0 +1,145 @@
+package logx
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// LoggerKey is the key holding the name of a named logger.
+const LoggerKey = "logger"
+
+// Named returns a logger that adds the LoggerKey field with name to every
+// entry. Names of nested named loggers are joined with dots, e.g. "db.pool",
+// and can be given their own level with ModuleLevels.
+func Named(l log.Logger, name string) log.Logger {
+	return log.With(l, LoggerKey, name)
+}
+
+// Named returns a Helper logging through Named(h.logger, name).
+func (h *Helper) Named(name string) *Helper {
+	return &Helper{
+		msgKey:  h.msgKey,
+		logger:  Named(h.logger, name),
+		fields:  h.fields,
+		sprint:  h.sprint,
+		sprintf: h.sprintf,
+	}
+}
+
+// ModuleLevels sets the minimum level per named logger, keyed by name or by
+// a path.Match pattern such as "db.*". Loggers without a match use the global
+// level. Exact names win over patterns, and longer patterns over shorter.
+func ModuleLevels(levels map[string]string) Option {
+	return func(l *Logger) {
+		l.modules = newModuleLevels(levels)
+	}
+}
+
+type moduleLevel struct {
+	pattern string
+	glob    bool
+	level   log.Level
+}
+
+type moduleLevels struct {
+	rules []moduleLevel
+	// name -> *moduleLevel, nil 表示没有匹配的规则
+	cache sync.Map
+}
+
+func newModuleLevels(levels map[string]string) *moduleLevels {
+	if len(levels) == 0 {
+		return nil
+	}
+	m := &moduleLevels{}
+	for pattern, level := range levels {
+		m.rules = append(m.rules, moduleLevel{
+			pattern: pattern,
+			glob:    strings.ContainsAny(pattern, `*?[\`),
+			level:   log.ParseLevel(level),
+		})
+	}
+	sort.Slice(m.rules, func(i, j int) bool {
+		a, b := m.rules[i], m.rules[j]
+		if a.glob != b.glob {
+			return !a.glob
+		}
+		if len(a.pattern) != len(b.pattern) {
+			return len(a.pattern) > len(b.pattern)
+		}
+		return a.pattern < b.pattern
+	})
+	return m
+}
+
+// lookup returns the level configured for name, if any.
+func (m *moduleLevels) lookup(name string) (log.Level, bool) {
+	if m == nil || name == "" {
+		return 0, false
+	}
+	if v, ok := m.cache.Load(name); ok {
+		if r := v.(*moduleLevel); r != nil {
+			return r.level, true
+		}
+		return 0, false
+	}
+	var found *moduleLevel
+	for i := range m.rules {
+		r := &m.rules[i]
+		if r.glob {
+			if ok, _ := path.Match(r.pattern, name); !ok {
+				continue
+			}
+		} else if r.pattern != name {
+			continue
+		}
+		found = r
+		break
+	}
+	m.cache.Store(name, found)
+	if found == nil {
+		return 0, false
+	}
+	return found.level, true
+}
+
+// loggerName returns the name of the named logger keyvals were logged with.
+// The LoggerKey fields added by nested Named calls are merged into one.
+func loggerName(keyvals []interface{}) (string, []interface{}) {
+	first, count := -1, 0
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		if keyvals[i] == LoggerKey {
+			if first < 0 {
+				first = i
+			}
+			count++
+		}
+	}
+	switch count {
+	case 0:
+		return "", keyvals
+	case 1:
+		return fmt.Sprint(keyvals[first+1]), keyvals
+	}
+
+	names := make([]string, 0, count)
+	kvs := make([]interface{}, 0, len(keyvals)-2*(count-1))
+	for i := 0; i < len(keyvals); i += 2 {
+		if i+1 < len(keyvals) && keyvals[i] == LoggerKey {
+			names = append(names, fmt.Sprint(keyvals[i+1]))
+			if i == first {
+				kvs = append(kvs, LoggerKey, nil)
+			}
+			continue
+		}
+		kvs = append(kvs, keyvals[i:min(i+2, len(keyvals))]...)
+	}
+	name := strings.Join(names, ".")
+	kvs[first+1] = name
+	return name, kvs
+}
@@ -0,0 +1,46 @@
+package logx
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+func TestNamedModuleLevels(t *testing.T) {
+	buf := &bytes.Buffer{}
+	conf := &LogxConf{
+		Level: LogLevel_WARN,
+		ModuleLevels: map[string]LogLevel{
+			"db.*":     LogLevel_DEBUG,
+			"db.cache": LogLevel_ERROR,
+		},
+	}
+	h := NewHelper(SetUpLog("", "", "", conf, Output(buf)))
+
+	h.Info("root")
+	h.Named("db").Info("db")
+	h.Named("db").Named("pool").Debug("pool")
+	h.Named("db").Named("cache").Warn("cache")
+
+	out := buf.String()
+	if strings.Count(out, "\n") != 1 {
+		t.Fatalf("expected exactly one line, got %q", out)
+	}
+	if !strings.Contains(out, "logger=db.pool") || strings.Count(out, "logger=") != 1 {
+		t.Fatalf("expected merged logger name, got %q", out)
+	}
+}
+
+func TestModuleLevelsLookup(t *testing.T) {
+	m := newModuleLevels(map[string]string{"a*": "ERROR", "ab*": "DEBUG", "abc": "WARN"})
+	for name, want := range map[string]log.Level{"abc": log.LevelWarn, "abd": log.LevelDebug, "az": log.LevelError} {
+		if got, ok := m.lookup(name); !ok || got != want {
+			t.Errorf("lookup(%q) = %v %v, want %v", name, got, ok, want)
+		}
+	}
+	if _, ok := m.lookup("b"); ok {
+		t.Error("lookup(b) should not match")
+	}
+}
@@ -1,32 +1,77 @@
 package logx
 
 import (
-	"bytes"
 	"fmt"
-	"github.com/go-kratos/kratos/v2/log"
 	"io"
-	golog "log"
-
+	"strconv"
 	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-kratos/kratos/v2/log"
 )
 
 var _ log.Logger = (*plainLogger)(nil)
 
+// plainMaxBuffer 超过该大小的缓冲区不放回 pool, 避免个别大日志长期占用内存
+const plainMaxBuffer = 64 * 1024
+
+var plainBufPool = sync.Pool{
+	New: func() interface{} {
+		b := make([]byte, 0, 512)
+		return &b
+	},
+}
+
+// entryWriter writes encoded entries, passing the level on to a LevelWriter.
+type entryWriter struct {
+	w  io.Writer
+	lw LevelWriter
+	// mu 保证非 LevelWriter 的每条日志一次写完
+	mu sync.Mutex
+}
+
+func newEntryWriter(w io.Writer) entryWriter {
+	lw, _ := w.(LevelWriter)
+	return entryWriter{w: w, lw: lw}
+}
+
+func getBuffer() *[]byte {
+	return plainBufPool.Get().(*[]byte)
+}
+
+// writeEntry writes buf, which must come from getBuffer, and puts it back
+// into the pool.
+func (ew *entryWriter) writeEntry(level log.Level, bp *[]byte, buf []byte) error {
+	var err error
+	if ew.lw != nil {
+		_, err = ew.lw.WriteLevel(level, buf)
+	} else {
+		ew.mu.Lock()
+		_, err = ew.w.Write(buf)
+		ew.mu.Unlock()
+	}
+	if cap(buf) <= plainMaxBuffer {
+		*bp = buf[:0]
+		plainBufPool.Put(bp)
+	}
+	return err
+}
+
+// Close flushes and closes the writer, unless it is stdout or stderr.
+func (ew *entryWriter) Close() error {
+	return closeWriter(ew.w)
+}
+
+// plainLogger encodes entries as logfmt, key=value pairs separated by
+// spaces, with the level following the first pair.
 type plainLogger struct {
-	log  *golog.Logger
-	pool *sync.Pool
+	entryWriter
 }
 
 // NewStdLogger new a logger with writer.
 func newPlainLogger(w io.Writer) *plainLogger {
-	return &plainLogger{
-		log: golog.New(w, "", 0),
-		pool: &sync.Pool{
-			New: func() interface{} {
-				return new(bytes.Buffer)
-			},
-		},
-	}
+	return &plainLogger{entryWriter: newEntryWriter(w)}
 }
 
 // Log print the kv pairs log.
@@ -37,21 +82,128 @@ func (l *plainLogger) Log(level log.Level, keyvals ...interface{}) error {
 	if (len(keyvals) & 1) == 1 {
 		keyvals = append(keyvals, "KEYVALS UNPAIRED")
 	}
-	buf := l.pool.Get().(*bytes.Buffer)
+	bp := getBuffer()
+	buf := (*bp)[:0]
 
 	for i := 0; i < len(keyvals); i += 2 {
+		if i > 0 {
+			buf = append(buf, ' ')
+		}
+		buf = appendLogfmtKey(buf, keyvals[i])
+		buf = append(buf, '=')
+		buf = appendLogfmtValue(buf, keyvals[i+1])
 		if i == 0 {
-			_, _ = fmt.Fprintf(buf, "%s=%v level=%s", keyvals[i], keyvals[i+1], level.String())
-			continue
+			buf = append(buf, " level="...)
+			buf = append(buf, level.String()...)
+		}
+	}
+	buf = append(buf, '\n')
+	return l.writeEntry(level, bp, buf)
+}
+
+// appendLogfmtKey appends key with the characters not allowed in logfmt
+// keys replaced by '_'.
+func appendLogfmtKey(buf []byte, key interface{}) []byte {
+	var s string
+	switch k := key.(type) {
+	case string:
+		s = k
+	default:
+		s = fmt.Sprint(k)
+	}
+	if s == "" {
+		return append(buf, '_')
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c <= ' ' || c == '=' || c == '"' || c == utf8.RuneSelf-1 {
+			c = '_'
+		}
+		buf = append(buf, c)
+	}
+	return buf
+}
+
+// appendLogfmtValue appends v, with fast paths for the common types and
+// quoting when needed.
+func appendLogfmtValue(buf []byte, v interface{}) []byte {
+	switch v := v.(type) {
+	case nil:
+		return append(buf, "null"...)
+	case string:
+		return appendLogfmtString(buf, v)
+	case []byte:
+		return appendLogfmtString(buf, string(v))
+	case bool:
+		return strconv.AppendBool(buf, v)
+	case int:
+		return strconv.AppendInt(buf, int64(v), 10)
+	case int8:
+		return strconv.AppendInt(buf, int64(v), 10)
+	case int16:
+		return strconv.AppendInt(buf, int64(v), 10)
+	case int32:
+		return strconv.AppendInt(buf, int64(v), 10)
+	case int64:
+		return strconv.AppendInt(buf, v, 10)
+	case uint:
+		return strconv.AppendUint(buf, uint64(v), 10)
+	case uint8:
+		return strconv.AppendUint(buf, uint64(v), 10)
+	case uint16:
+		return strconv.AppendUint(buf, uint64(v), 10)
+	case uint32:
+		return strconv.AppendUint(buf, uint64(v), 10)
+	case uint64:
+		return strconv.AppendUint(buf, v, 10)
+	case float32:
+		return strconv.AppendFloat(buf, float64(v), 'g', -1, 32)
+	case float64:
+		return strconv.AppendFloat(buf, v, 'g', -1, 64)
+	case time.Time:
+		return v.AppendFormat(buf, time.RFC3339Nano)
+	case time.Duration:
+		return append(buf, v.String()...)
+	case error:
+		if !isNilPtr(v) {
+			return appendLogfmtString(buf, v.Error())
+		}
+	case fmt.Stringer:
+		if !isNilPtr(v) {
+			return appendLogfmtString(buf, v.String())
 		}
-		_, _ = fmt.Fprintf(buf, " %s=%v", keyvals[i], keyvals[i+1])
 	}
-	_ = l.log.Output(4, buf.String()) //nolint:gomnd
-	buf.Reset()
-	l.pool.Put(buf)
-	return nil
+	// nil 指针交给 fmt, 方法 panic 时输出 <nil>
+	return appendLogfmtString(buf, fmt.Sprint(v))
+}
+
+// appendLogfmtString appends s, quoted if it is empty or contains spaces,
+// '=', '"', control characters or invalid UTF-8.
+func appendLogfmtString(buf []byte, s string) []byte {
+	if !logfmtNeedsQuote(s) {
+		return append(buf, s...)
+	}
+	return strconv.AppendQuote(buf, s)
 }
 
-func (l *plainLogger) Close() error {
-	return nil
+func logfmtNeedsQuote(s string) bool {
+	if s == "" {
+		return true
+	}
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c < utf8.RuneSelf {
+			if c <= ' ' || c == '=' || c == '"' || c == '\\' || c == utf8.RuneSelf-1 {
+				return true
+			}
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			return true
+		}
+		i += size
+	}
+	return false
 }
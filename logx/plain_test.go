@@ -0,0 +1,97 @@
+package logx
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	golog "log"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+func TestPlainLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := newPlainLogger(&buf)
+	_ = l.Log(log.LevelWarn,
+		"ts", "2026-10-19T10:00:00+08:00",
+		"msg", `say "hi"`,
+		"path", "a=b",
+		"empty", "",
+		"n", -3, "ok", true, "ratio", 0.25,
+		"took", 1500*time.Millisecond,
+		"err", errors.New("line1\nline2"),
+		"my key", nil,
+		"name", "张三",
+	)
+	want := `ts=2026-10-19T10:00:00+08:00 level=WARN msg="say \"hi\"" path="a=b" empty="" n=-3 ok=true ratio=0.25 ` +
+		`took=1.5s err="line1\nline2" my_key=null name=张三` + "\n"
+	if buf.String() != want {
+		t.Errorf("got  %s\nwant %s", buf.String(), want)
+	}
+
+	buf.Reset()
+	_ = l.Log(log.LevelInfo, "msg")
+	if buf.String() != "msg=\"KEYVALS UNPAIRED\" level=INFO\n" {
+		t.Errorf("unpaired: %q", buf.String())
+	}
+}
+
+func TestPlainLoggerTypedNil(t *testing.T) {
+	var buf bytes.Buffer
+	_ = newPlainLogger(&buf).Log(log.LevelError, "err", (*stackError)(nil), "s", (*nilStringer)(nil))
+	if want := "err=<nil> level=ERROR s=<nil>\n"; buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
+
+// legacyPlainLogger is the fmt based implementation replaced by plainLogger,
+// kept to compare the benchmarks.
+type legacyPlainLogger struct {
+	log  *golog.Logger
+	pool *sync.Pool
+}
+
+func (l *legacyPlainLogger) Log(level log.Level, keyvals ...interface{}) error {
+	buf := l.pool.Get().(*bytes.Buffer)
+	for i := 0; i < len(keyvals); i += 2 {
+		if i == 0 {
+			_, _ = fmt.Fprintf(buf, "%s=%v level=%s", keyvals[i], keyvals[i+1], level.String())
+			continue
+		}
+		_, _ = fmt.Fprintf(buf, " %s=%v", keyvals[i], keyvals[i+1])
+	}
+	_ = l.log.Output(4, buf.String())
+	buf.Reset()
+	l.pool.Put(buf)
+	return nil
+}
+
+func benchmarkPlain(b *testing.B, l log.Logger) {
+	ts := time.Date(2026, 10, 19, 10, 0, 0, 0, time.Local).Format(time.RFC3339)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = l.Log(log.LevelInfo,
+			"ts", ts,
+			"caller", "logx/plain_test.go:42",
+			"msg", "request finished",
+			"status", 200,
+			"latency", 3*time.Millisecond,
+			"path", "/api/v1/users",
+		)
+	}
+}
+
+func BenchmarkPlainLogger(b *testing.B) {
+	benchmarkPlain(b, newPlainLogger(io.Discard))
+}
+
+func BenchmarkLegacyPlainLogger(b *testing.B) {
+	benchmarkPlain(b, &legacyPlainLogger{
+		log:  golog.New(io.Discard, "", 0),
+		pool: &sync.Pool{New: func() interface{} { return new(bytes.Buffer) }},
+	})
+}
@@ -0,0 +1,590 @@
+package logx
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	redactMask = "******"
+	// redactMaxDepth 限制嵌套结构的遍历深度, 避免循环引用
+	redactMaxDepth = 8
+	redactCacheMax = 1024
+)
+
+// Built-in value patterns of LogxRedactRule.Value.
+const (
+	PatternEmail = "email"
+	PatternPhone = "phone"
+	PatternCard  = "card"
+	PatternToken = "token"
+)
+
+type valuePattern struct {
+	re *regexp.Regexp
+	// check 过滤正则的误匹配, 例如校验卡号的 Luhn 校验位
+	check func(string) bool
+	mask  func(string) string
+}
+
+var valuePatterns = map[string]valuePattern{
+	PatternEmail: {
+		re:   regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`),
+		mask: maskEmail,
+	},
+	PatternPhone: {
+		re:   regexp.MustCompile(`(?:\+86[ \-]?|\b86[ \-]?|\b)1[3-9]\d{9}\b`),
+		mask: maskPhone,
+	},
+	PatternCard: {
+		re:    regexp.MustCompile(`\b\d(?:[ \-]?\d){12,18}\b`),
+		check: luhn,
+		mask:  func(s string) string { return maskDigits(s, 0, 4) },
+	},
+	PatternToken: {
+		re:   regexp.MustCompile(`(?i)\bbearer\s+[A-Za-z0-9._~+/\-]+=*|\beyJ[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]+`),
+		mask: maskToken,
+	},
+}
+
+// RedactOption is Redactor option.
+type RedactOption func(*Redactor)
+
+// RedactSalt sets the HMAC key of the hash strategy.
+func RedactSalt(salt []byte) RedactOption {
+	return func(r *Redactor) {
+		r.salt = salt
+	}
+}
+
+// RedactEncryptor sets the Encryptor of the encrypt strategy.
+func RedactEncryptor(e Encryptor) RedactOption {
+	return func(r *Redactor) {
+		r.encryptor = e
+	}
+}
+
+type redactRule struct {
+	key      string
+	match    KeyMatch
+	keyRe    *regexp.Regexp
+	value    *valuePattern
+	strategy RedactStrategy
+	// fn 为 EntryptionFn 设置的替换函数, 优先于 strategy
+	fn func(string) string
+}
+
+// Redactor rewrites sensitive fields before they are encoded. A rule either
+// matches a key, exactly, by glob or by regexp, and replaces the whole value,
+// or matches a value pattern and replaces only the matching parts of string
+// values. Structs, maps and slices are walked and nested fields are matched
+// by name or dotted path, e.g. "user.password".
+type Redactor struct {
+	rules     []redactRule
+	salt      []byte
+	encryptor Encryptor
+
+	// key -> *keyRules, 只缓存顶层字段
+	cache     sync.Map
+	cacheSize int
+	cacheMu   sync.Mutex
+}
+
+type keyRules struct {
+	whole  *redactRule
+	values []*redactRule
+}
+
+// NewRedactor returns a Redactor applying rules in order.
+func NewRedactor(rules []*LogxRedactRule, opts ...RedactOption) (*Redactor, error) {
+	r := &Redactor{}
+	for _, o := range opts {
+		o(r)
+	}
+	for _, c := range rules {
+		rule, err := r.compileRule(c)
+		if err != nil {
+			return nil, err
+		}
+		r.rules = append(r.rules, rule)
+	}
+	return r, nil
+}
+
+func (r *Redactor) compileRule(c *LogxRedactRule) (redactRule, error) {
+	rule := redactRule{key: c.Key, match: c.Match, strategy: c.Strategy}
+	if c.Key == "" && c.Value == "" {
+		return rule, errors.New("logx: redact rule without key and value")
+	}
+	if c.Match == KeyMatch_regex {
+		re, err := regexp.Compile(c.Key)
+		if err != nil {
+			return rule, fmt.Errorf("logx: redact key %q: %w", c.Key, err)
+		}
+		rule.keyRe = re
+	}
+	if c.Value != "" {
+		p, ok := valuePatterns[c.Value]
+		if !ok {
+			re, err := regexp.Compile(c.Value)
+			if err != nil {
+				return rule, fmt.Errorf("logx: redact value %q: %w", c.Value, err)
+			}
+			p = valuePattern{re: re}
+		}
+		rule.value = &p
+	}
+	if c.Strategy == RedactStrategy_encrypt && r.encryptor == nil {
+		return rule, errors.New("logx: redact encrypt strategy without key")
+	}
+	return rule, nil
+}
+
+// failClosedRedactor returns a Redactor for rules rejected by NewRedactor
+// that masks rather than leaks: rules that do not compile mask the whole
+// value, an invalid key regexp matches every key, and encrypt without a key
+// masks.
+func failClosedRedactor(rules []*LogxRedactRule, opts ...RedactOption) *Redactor {
+	r := &Redactor{}
+	for _, o := range opts {
+		o(r)
+	}
+	for _, c := range rules {
+		rule, err := r.compileRule(c)
+		if err != nil {
+			rule = redactRule{key: c.Key, match: c.Match, strategy: RedactStrategy_mask}
+			if c.Match == KeyMatch_regex {
+				if rule.keyRe, err = regexp.Compile(c.Key); err != nil {
+					rule.keyRe = regexp.MustCompile("")
+				}
+			}
+		}
+		r.rules = append(r.rules, rule)
+	}
+	return r
+}
+
+// Redact returns keyvals with the rules applied. keyvals is never modified,
+// a copy is returned if any field is redacted.
+func (r *Redactor) Redact(keyvals []interface{}) []interface{} {
+	if r == nil || len(r.rules) == 0 {
+		return keyvals
+	}
+	var out []interface{}
+	for i := 0; i < len(keyvals); i += 2 {
+		if i+1 >= len(keyvals) {
+			if out != nil {
+				out = append(out, keyvals[i])
+			}
+			break
+		}
+		key := fmt.Sprint(keyvals[i])
+		v, changed, drop := r.redact(r.topRules(key), key, key, keyvals[i+1], 0)
+		if out == nil && (changed || drop) {
+			out = make([]interface{}, i, len(keyvals))
+			copy(out, keyvals[:i])
+		}
+		if out != nil && !drop {
+			out = append(out, keyvals[i], v)
+		}
+	}
+	if out == nil {
+		return keyvals
+	}
+	return out
+}
+
+func (r *Redactor) topRules(key string) *keyRules {
+	if v, ok := r.cache.Load(key); ok {
+		return v.(*keyRules)
+	}
+	kr := r.match(key, key)
+	r.cacheMu.Lock()
+	// 字段名通常是固定的, 超过上限时不再缓存
+	if r.cacheSize < redactCacheMax {
+		r.cache.Store(key, kr)
+		r.cacheSize++
+	}
+	r.cacheMu.Unlock()
+	return kr
+}
+
+// match returns the rules applying to the field name at path.
+func (r *Redactor) match(name, path string) *keyRules {
+	kr := &keyRules{}
+	for i := range r.rules {
+		rule := &r.rules[i]
+		if rule.key != "" && !rule.matchKey(name, path) {
+			continue
+		}
+		if rule.value != nil {
+			kr.values = append(kr.values, rule)
+		} else if kr.whole == nil {
+			kr.whole = rule
+		}
+	}
+	return kr
+}
+
+func (rule *redactRule) matchKey(name, p string) bool {
+	switch rule.match {
+	case KeyMatch_glob:
+		if ok, _ := path.Match(rule.key, name); ok {
+			return true
+		}
+		ok, _ := path.Match(rule.key, p)
+		return ok
+	case KeyMatch_regex:
+		return rule.keyRe.MatchString(name) || rule.keyRe.MatchString(p)
+	default:
+		return rule.key == name || rule.key == p
+	}
+}
+
+// redact returns the redacted v, whether it changed and whether the field
+// is dropped.
+func (r *Redactor) redact(kr *keyRules, name, path string, v interface{}, depth int) (interface{}, bool, bool) {
+	if kr.whole != nil {
+		if kr.whole.strategy == RedactStrategy_drop && kr.whole.fn == nil {
+			return nil, false, true
+		}
+		return r.apply(kr.whole, stringify(v), nil), true, false
+	}
+	if s, ok := v.(string); ok {
+		ns, drop := r.redactString(kr.values, s)
+		return ns, ns != s, drop
+	}
+	if depth < redactMaxDepth {
+		if nv, changed, drop := r.redactNested(name, path, v, depth); changed || drop {
+			return nv, changed, drop
+		}
+	}
+	if len(kr.values) > 0 {
+		// error 和 Stringer 按输出的文本匹配
+		if text, ok := renderedText(v); ok {
+			ns, drop := r.redactString(kr.values, text)
+			if drop || ns != text {
+				return ns, true, drop
+			}
+		}
+	}
+	return v, false, false
+}
+
+// renderedText returns the text the encoders log for errors and Stringers.
+func renderedText(v interface{}) (string, bool) {
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return "", false
+	}
+	switch v := v.(type) {
+	case error:
+		return v.Error(), true
+	case fmt.Stringer:
+		return v.String(), true
+	}
+	return "", false
+}
+
+func (r *Redactor) redactString(rules []*redactRule, s string) (string, bool) {
+	for _, rule := range rules {
+		p := rule.value
+		if rule.strategy == RedactStrategy_drop && rule.fn == nil {
+			if p.find(s) {
+				return "", true
+			}
+			continue
+		}
+		s = p.re.ReplaceAllStringFunc(s, func(m string) string {
+			if p.check != nil && !p.check(m) {
+				return m
+			}
+			return r.apply(rule, m, p.mask)
+		})
+	}
+	return s, false
+}
+
+func (p *valuePattern) find(s string) bool {
+	if p.check == nil {
+		return p.re.MatchString(s)
+	}
+	for _, m := range p.re.FindAllString(s, -1) {
+		if p.check(m) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Redactor) apply(rule *redactRule, s string, mask func(string) string) string {
+	if rule.fn != nil {
+		return rule.fn(s)
+	}
+	switch rule.strategy {
+	case RedactStrategy_hash:
+		h := hmac.New(sha256.New, r.salt)
+		h.Write([]byte(s))
+		return "sha256:" + hex.EncodeToString(h.Sum(nil))[:16]
+	case RedactStrategy_encrypt:
+		if enc, err := r.encryptor.Encrypt([]byte(s)); err == nil {
+			return enc
+		}
+	case RedactStrategy_mask:
+		if mask != nil {
+			return mask(s)
+		}
+	}
+	// 加密失败时也不输出原值
+	return redactMask
+}
+
+// redactNested walks proto messages, structs, maps, slices and pointers to
+// them, also if they implement error, fmt.Stringer or json.Marshaler.
+// Changed messages, structs and maps are returned as
+// map[string]interface{}, slices as []interface{}.
+func (r *Redactor) redactNested(name, path string, v interface{}, depth int) (interface{}, bool, bool) {
+	switch v := v.(type) {
+	case nil, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64,
+		float32, float64, []byte:
+		return v, false, false
+	case proto.Message:
+		if m := v.ProtoReflect(); m.IsValid() {
+			if out, changed := r.redactProto(path, m, depth); changed {
+				return out, true, false
+			}
+		}
+		return v, false, false
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			return v, false, false
+		}
+		if nv, changed, _ := r.redactNested(name, path, rv.Elem().Interface(), depth+1); changed {
+			return nv, true, false
+		}
+	case reflect.Map:
+		out := make(map[string]interface{}, rv.Len())
+		changed := false
+		iter := rv.MapRange()
+		for iter.Next() {
+			k := fmt.Sprint(iter.Key().Interface())
+			p := path + "." + k
+			nv, ch, drop := r.redact(r.match(k, p), k, p, iter.Value().Interface(), depth+1)
+			changed = changed || ch || drop
+			if !drop {
+				out[k] = nv
+			}
+		}
+		if changed {
+			return out, true, false
+		}
+	case reflect.Struct:
+		rt := rv.Type()
+		out := make(map[string]interface{}, rt.NumField())
+		changed := false
+		for i := 0; i < rt.NumField(); i++ {
+			f := rt.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			k := f.Name
+			if tag, _, _ := strings.Cut(f.Tag.Get("json"), ","); tag == "-" {
+				continue
+			} else if tag != "" {
+				k = tag
+			}
+			p := path + "." + k
+			nv, ch, drop := r.redact(r.match(k, p), k, p, rv.Field(i).Interface(), depth+1)
+			changed = changed || ch || drop
+			if !drop {
+				out[k] = nv
+			}
+		}
+		if changed {
+			return out, true, false
+		}
+	case reflect.Slice, reflect.Array:
+		out := make([]interface{}, rv.Len())
+		changed := false
+		kr := r.match(name, path)
+		for i := range out {
+			nv, ch, drop := r.redact(kr, name, path, rv.Index(i).Interface(), depth+1)
+			changed = changed || ch || drop
+			out[i] = nv
+		}
+		if changed {
+			return out, true, false
+		}
+	}
+	return v, false, false
+}
+
+// redactProto walks the populated fields of m by their proto names, e.g.
+// aes_key.
+func (r *Redactor) redactProto(path string, m protoreflect.Message, depth int) (map[string]interface{}, bool) {
+	out := make(map[string]interface{})
+	changed := false
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		k := string(fd.Name())
+		p := path + "." + k
+		nv, ch, drop := r.redact(r.match(k, p), k, p, protoValue(fd, v), depth+1)
+		changed = changed || ch || drop
+		if !drop {
+			out[k] = nv
+		}
+		return true
+	})
+	return out, changed
+}
+
+// protoValue converts the value v of fd to Go values: lists to
+// []interface{}, maps to map[string]interface{}, see protoScalar for the
+// rest.
+func protoValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) interface{} {
+	switch {
+	case fd.IsList():
+		l := v.List()
+		out := make([]interface{}, l.Len())
+		for i := range out {
+			out[i] = protoScalar(fd, l.Get(i))
+		}
+		return out
+	case fd.IsMap():
+		out := make(map[string]interface{}, v.Map().Len())
+		v.Map().Range(func(k protoreflect.MapKey, mv protoreflect.Value) bool {
+			out[k.String()] = protoScalar(fd.MapValue(), mv)
+			return true
+		})
+		return out
+	}
+	return protoScalar(fd, v)
+}
+
+// protoScalar converts messages to proto.Message and enums to their names.
+func protoScalar(fd protoreflect.FieldDescriptor, v protoreflect.Value) interface{} {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return v.Message().Interface()
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return string(ev.Name())
+		}
+		return int32(v.Enum())
+	}
+	return v.Interface()
+}
+
+func stringify(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	}
+	return fmt.Sprint(v)
+}
+
+func maskEmail(s string) string {
+	at := strings.LastIndexByte(s, '@')
+	if at <= 0 {
+		return redactMask
+	}
+	return s[:1] + "***" + s[at:]
+}
+
+// maskPhone keeps the first 3 and last 4 digits of the number without the
+// country code.
+func maskPhone(s string) string {
+	return maskDigits(s, countDigits(s)-8, 4)
+}
+
+func countDigits(s string) int {
+	n := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] >= '0' && s[i] <= '9' {
+			n++
+		}
+	}
+	return n
+}
+
+// maskDigits replaces the digits of s with '*', except the first head and
+// the last tail digits.
+func maskDigits(s string, head, tail int) string {
+	total := countDigits(s)
+	b := []byte(s)
+	n := 0
+	for i := range b {
+		if b[i] < '0' || b[i] > '9' {
+			continue
+		}
+		if n >= head && n < total-tail {
+			b[i] = '*'
+		}
+		n++
+	}
+	return string(b)
+}
+
+func maskToken(s string) string {
+	if i := strings.IndexAny(s, " \t"); i > 0 {
+		return s[:i+1] + redactMask
+	}
+	return redactMask
+}
+
+// luhn reports whether the digits of s pass the Luhn checksum.
+func luhn(s string) bool {
+	sum, n := 0, 0
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if n%2 == 1 {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		n++
+	}
+	return n > 0 && sum%10 == 0
+}
+
+// withFunc returns a copy of r replacing the values of keys with fn, before
+// its own rules.
+func (r *Redactor) withFunc(keys []string, fn func(string) string) *Redactor {
+	nr := &Redactor{}
+	if r != nil {
+		nr.salt, nr.encryptor = r.salt, r.encryptor
+	}
+	for _, key := range keys {
+		nr.rules = append(nr.rules, redactRule{key: key, fn: fn})
+	}
+	if r != nil {
+		nr.rules = append(nr.rules, r.rules...)
+	}
+	return nr
+}
+
+// Redact makes the logger apply r to every entry.
+func Redact(r *Redactor) Option {
+	return func(l *Logger) {
+		l.redactor = r
+	}
+}
@@ -0,0 +1,168 @@
+package logx
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+func TestRedactorRedact(t *testing.T) {
+	type user struct {
+		Name     string `json:"name"`
+		Password string `json:"password"`
+		Email    string
+		internal string
+	}
+	r, err := NewRedactor([]*LogxRedactRule{
+		{Key: "password", Strategy: RedactStrategy_mask},
+		{Key: "*_token", Match: KeyMatch_glob, Strategy: RedactStrategy_drop},
+		{Key: "^secret", Match: KeyMatch_regex, Strategy: RedactStrategy_hash},
+		{Value: PatternEmail},
+		{Value: PatternPhone},
+		{Value: PatternCard},
+		{Key: "auth", Value: PatternToken},
+	}, RedactSalt([]byte("salt")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	kvs := []interface{}{
+		"msg", "login a.b@example.com from 13812345678",
+		"password", 123456,
+		"access_token", "abc",
+		"secret_id", "s1",
+		"card", "4111 1111 1111 1111",
+		"order", "1234567890123",
+		"auth", "Bearer abc.def",
+		"user", &user{Name: "bob", Password: "pw", Email: "bob@example.com", internal: "x"},
+		"count", 3,
+	}
+	orig := append([]interface{}(nil), kvs...)
+	got := r.Redact(kvs)
+	if !reflect.DeepEqual(kvs, orig) {
+		t.Fatalf("keyvals modified: %v", kvs)
+	}
+
+	want := []interface{}{
+		"msg", "login a***@example.com from 138****5678",
+		"password", "******",
+		"secret_id", "sha256:" + got[5].(string)[7:],
+		"card", "**** **** **** 1111",
+		"order", "1234567890123",
+		"auth", "Bearer ******",
+		"user", map[string]interface{}{"name": "bob", "password": "******", "Email": "b***@example.com"},
+		"count", 3,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got  %#v\nwant %#v", got, want)
+	}
+	if h := r.Redact([]interface{}{"secret_id", "s1"})[1]; h != got[5] || len(h.(string)) != len("sha256:")+16 {
+		t.Errorf("hash not stable: %v, %v", h, got[5])
+	}
+
+	plain := []interface{}{"msg", "nothing here", "n", 1}
+	if out := r.Redact(plain); &out[0] != &plain[0] {
+		t.Error("keyvals without matches should not be copied")
+	}
+}
+
+func TestRedactEncrypt(t *testing.T) {
+	enc, err := NewAESEncryptor(bytes.Repeat([]byte{1}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := NewRedactor([]*LogxRedactRule{{Key: "id_card", Strategy: RedactStrategy_encrypt}}, RedactEncryptor(enc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	l := NewLogger(Output(&buf), Encoding(encoding_json), Redact(r))
+	_ = l.Log(log.LevelInfo, "msg", "hi", "id_card", "110101199003077777")
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := entry["id_card"].(string); !strings.HasPrefix(v, "enc:") || strings.Contains(v, "110101") {
+		t.Errorf("id_card = %q, want encrypted", entry["id_card"])
+	}
+
+	if _, err := NewRedactor([]*LogxRedactRule{{Key: "id_card", Strategy: RedactStrategy_encrypt}}); err == nil {
+		t.Error("encrypt without key: want error")
+	}
+}
+
+func TestFilterKeyDoesNotMutate(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(Output(&buf), FilterKey("args"), EntryptionFn(func(string) string { return "x" }))
+	kvs := []interface{}{"msg", "call", "args", "secret"}
+	_ = l.Log(log.LevelInfo, kvs...)
+	if kvs[3] != "secret" {
+		t.Errorf("keyvals modified: %v", kvs)
+	}
+	if !strings.Contains(buf.String(), "args=x") {
+		t.Errorf("output %q, want args=x", buf.String())
+	}
+}
+
+type stringerRequest struct {
+	Password string `json:"password"`
+}
+
+func (r *stringerRequest) String() string { return "request" }
+
+func TestRedactProtoStringerAndError(t *testing.T) {
+	r, err := NewRedactor([]*LogxRedactRule{
+		{Key: "aes_key"},
+		{Key: "password"},
+		{Value: PatternEmail},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf := &LogxConf{Level: LogLevel_WARN, Redact: &LogxRedact{AesKey: "SECRETKEY", HashSalt: "salt"}}
+	got := r.Redact([]interface{}{
+		"conf", conf,
+		"req", &stringerRequest{Password: "hunter2"},
+		"err", fmt.Errorf("login: %w", errors.New("user a.b@example.com failed")),
+		"nil", (*stringerRequest)(nil),
+	})
+
+	want := []interface{}{
+		"conf", map[string]interface{}{
+			"level":  "WARN",
+			"redact": map[string]interface{}{"aes_key": "******", "hash_salt": "salt"},
+		},
+		"req", map[string]interface{}{"password": "******"},
+		"err", "login: user a***@example.com failed",
+		"nil", (*stringerRequest)(nil),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got  %#v\nwant %#v", got, want)
+	}
+	if conf.Redact.AesKey != "SECRETKEY" {
+		t.Error("proto message modified")
+	}
+}
+
+func TestConfRedactFailsClosed(t *testing.T) {
+	for name, c := range map[string]*LogxRedact{
+		"missing key file": {AesKeyFile: "/nonexistent/keys.json", Rules: []*LogxRedactRule{{Key: "secret", Strategy: RedactStrategy_encrypt}}},
+		"bad aes_key":      {AesKey: "not base64!", Rules: []*LogxRedactRule{{Key: "secret", Strategy: RedactStrategy_encrypt}}},
+		"bad value regexp": {Rules: []*LogxRedactRule{{Key: "secret", Value: "("}}},
+		"bad key regexp":   {Rules: []*LogxRedactRule{{Key: "(", Match: KeyMatch_regex}}},
+	} {
+		var buf bytes.Buffer
+		l := NewConfLogger(&LogxConf{Redact: c}, Output(&buf))
+		_ = l.Log(log.LevelInfo, "secret", "hunter2")
+		if !strings.Contains(buf.String(), "secret=******") {
+			t.Errorf("%s: got %s", name, buf.String())
+		}
+	}
+}
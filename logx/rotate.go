@@ -0,0 +1,304 @@
+package logx
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/zhaoqiang0201/pkg/clock"
+)
+
+var _ io.WriteCloser = (*TimeRotateWriter)(nil)
+
+const (
+	hourlyLayout = "2006-01-02-15"
+	dailyLayout  = "2006-01-02"
+)
+
+// TimeRotateWriter writes to a file that is switched every hour or day, e.g.
+// service-2026-10-18.log, keeping a symlink service.log to the current one.
+// Rotated files are optionally compressed, and removed once they are older
+// than KeepDays or more than MaxBackups exist.
+type TimeRotateWriter struct {
+	dir         string
+	filename    string
+	prefix, ext string
+	layout      string
+	rotation    Rotation
+	loc         *time.Location
+	keepDays    int
+	maxBackups  int
+	compression Compression
+	clock       clock.PassiveClock
+	mode        os.FileMode
+
+	mu   sync.Mutex
+	file *os.File
+	next time.Time
+
+	millCh   chan struct{}
+	millDone chan struct{}
+}
+
+// NewTimeRotateWriter returns a TimeRotateWriter for dir/filename rotated
+// by rotation at the boundaries of loc, Local if nil. The file is opened on
+// the first write.
+func NewTimeRotateWriter(dir, filename string, rotation Rotation, loc *time.Location, keepDays, maxBackups int, compression Compression, c clock.PassiveClock) *TimeRotateWriter {
+	if loc == nil {
+		loc = time.Local
+	}
+	if c == nil {
+		c = clock.RealClock{}
+	}
+	ext := filepath.Ext(filename)
+	w := &TimeRotateWriter{
+		dir:         dir,
+		filename:    filename,
+		prefix:      strings.TrimSuffix(filename, ext) + "-",
+		ext:         ext,
+		layout:      dailyLayout,
+		rotation:    rotation,
+		loc:         loc,
+		keepDays:    keepDays,
+		maxBackups:  maxBackups,
+		compression: compression,
+		clock:       c,
+		millCh:      make(chan struct{}, 1),
+		millDone:    make(chan struct{}),
+	}
+	if rotation == Rotation_hourly {
+		w.layout = hourlyLayout
+	}
+	go w.millRun(w.millCh)
+	return w
+}
+
+func (w *TimeRotateWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if now := w.clock.Now(); w.file == nil || !now.Before(w.next) {
+		if err := w.rotateLocked(now); err != nil {
+			return 0, err
+		}
+	}
+	return w.file.Write(p)
+}
+
+// Sync commits the current file to stable storage.
+func (w *TimeRotateWriter) Sync() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.file == nil {
+		return nil
+	}
+	return w.file.Sync()
+}
+
+// Rotate reopens the file of the current period, e.g. after logrotate moved
+// it away.
+func (w *TimeRotateWriter) Rotate() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.rotateLocked(w.clock.Now())
+}
+
+// Close closes the current file and waits for pending compression.
+func (w *TimeRotateWriter) Close() error {
+	w.mu.Lock()
+	millCh := w.millCh
+	w.millCh = nil
+	w.mu.Unlock()
+	if millCh == nil {
+		return nil
+	}
+	close(millCh)
+	<-w.millDone
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
+
+func (w *TimeRotateWriter) rotateLocked(now time.Time) error {
+	if w.millCh == nil {
+		return ErrWriterClosed
+	}
+	start, next := w.period(now)
+	name := w.prefix + start.Format(w.layout) + w.ext
+	if err := os.MkdirAll(w.dir, 0o755); err != nil {
+		return fmt.Errorf("logx: create log dir: %w", err)
+	}
+	mode := w.mode
+	if mode == 0 {
+		mode = 0o644
+	}
+	f, err := os.OpenFile(filepath.Join(w.dir, name), os.O_CREATE|os.O_WRONLY|os.O_APPEND, mode)
+	if err != nil {
+		return fmt.Errorf("logx: open log file: %w", err)
+	}
+	if w.file != nil {
+		_ = w.file.Close()
+	}
+	w.file = f
+	w.next = next
+	if err := w.linkLocked(name); err != nil {
+		fmt.Fprintf(os.Stderr, "logx: %v\n", err)
+	}
+
+	select {
+	case w.millCh <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// period returns the start of the rotation period containing now and the
+// start of the next one.
+func (w *TimeRotateWriter) period(now time.Time) (time.Time, time.Time) {
+	t := now.In(w.loc)
+	if w.rotation == Rotation_hourly {
+		start := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, w.loc)
+		return start, start.Add(time.Hour)
+	}
+	start := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, w.loc)
+	return start, start.AddDate(0, 0, 1)
+}
+
+// linkLocked points the symlink dir/filename to name. Only a missing file or
+// a symlink is replaced: a regular file left there by size rotation is kept
+// and an error returned instead.
+func (w *TimeRotateWriter) linkLocked(name string) error {
+	link := filepath.Join(w.dir, w.filename)
+	if fi, err := os.Lstat(link); err == nil && fi.Mode()&os.ModeSymlink == 0 {
+		return fmt.Errorf("link %s: not a symlink, kept", link)
+	}
+	tmp := link + ".tmp"
+	_ = os.Remove(tmp)
+	if err := os.Symlink(name, tmp); err != nil {
+		return fmt.Errorf("link %s: %w", link, err)
+	}
+	if err := os.Rename(tmp, link); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("link %s: %w", link, err)
+	}
+	return nil
+}
+
+func (w *TimeRotateWriter) millRun(millCh <-chan struct{}) {
+	defer close(w.millDone)
+	for range millCh {
+		_ = w.mill()
+	}
+}
+
+type rotatedFile struct {
+	name string
+	t    time.Time
+}
+
+// mill compresses the rotated files and removes the expired ones. A file that
+// fails to compress does not stop the others.
+func (w *TimeRotateWriter) mill() error {
+	w.mu.Lock()
+	var current string
+	if w.file != nil {
+		current = filepath.Base(w.file.Name())
+	}
+	w.mu.Unlock()
+
+	entries, err := os.ReadDir(w.dir)
+	if err != nil {
+		return err
+	}
+	var files []rotatedFile
+	for _, e := range entries {
+		name := e.Name()
+		if !e.Type().IsRegular() || name == current || !strings.HasPrefix(name, w.prefix) {
+			continue
+		}
+		ts := strings.TrimPrefix(name, w.prefix)
+		ts = strings.TrimSuffix(strings.TrimSuffix(ts, ".gz"), ".zst")
+		t, err := time.ParseInLocation(w.layout, strings.TrimSuffix(ts, w.ext), w.loc)
+		if err != nil {
+			continue
+		}
+		files = append(files, rotatedFile{name: name, t: t})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].t.After(files[j].t) })
+
+	var cutoff time.Time
+	if w.keepDays > 0 {
+		cutoff = w.clock.Now().AddDate(0, 0, -w.keepDays)
+	}
+	var errs []error
+	for i, f := range files {
+		if (w.maxBackups > 0 && i >= w.maxBackups) || (!cutoff.IsZero() && f.t.Before(cutoff)) {
+			_ = os.Remove(filepath.Join(w.dir, f.name))
+			continue
+		}
+		if strings.HasSuffix(f.name, w.ext) {
+			if err := compressFile(filepath.Join(w.dir, f.name), w.compression); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// compressFile compresses src to src.gz or src.zst and removes src.
+func compressFile(src string, compression Compression) error {
+	var ext string
+	switch compression {
+	case Compression_gzip:
+		ext = ".gz"
+	case Compression_zstd:
+		ext = ".zst"
+	default:
+		return nil
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+	out, err := os.OpenFile(src+ext, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, info.Mode())
+	if err != nil {
+		return err
+	}
+
+	var zw io.WriteCloser
+	if compression == Compression_gzip {
+		zw = gzip.NewWriter(out)
+	} else if zw, err = zstd.NewWriter(out); err != nil {
+		_ = out.Close()
+		return err
+	}
+	if _, err = io.Copy(zw, in); err == nil {
+		err = zw.Close()
+	}
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		_ = os.Remove(src + ext)
+		return err
+	}
+	return os.Remove(src)
+}
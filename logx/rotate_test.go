@@ -0,0 +1,105 @@
+package logx
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	testingclock "github.com/zhaoqiang0201/pkg/clock/testing"
+)
+
+func TestTimeRotateWriterDaily(t *testing.T) {
+	dir := t.TempDir()
+	loc := time.FixedZone("CST", 8*3600)
+	fc := testingclock.NewFakePassiveClock(time.Date(2026, 10, 17, 23, 59, 0, 0, loc))
+	w := NewTimeRotateWriter(dir, "service.log", Rotation_daily, loc, 0, 1, Compression_gzip, fc)
+
+	for _, day := range []int{17, 18, 19} {
+		fc.SetTime(time.Date(2026, 10, day, 23, 59, 0, 0, loc))
+		if _, err := w.Write([]byte("line\n")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, exists := range map[string]bool{
+		"service-2026-10-17.log.gz": false,
+		"service-2026-10-18.log.gz": true,
+		"service-2026-10-19.log":    true,
+	} {
+		if _, err := os.Stat(filepath.Join(dir, name)); (err == nil) != exists {
+			t.Errorf("%s: exists=%v, want %v", name, err == nil, exists)
+		}
+	}
+	if target, err := os.Readlink(filepath.Join(dir, "service.log")); err != nil || target != "service-2026-10-19.log" {
+		t.Errorf("symlink -> %q, %v", target, err)
+	}
+}
+
+func TestLoggerFileModeAndRotate(t *testing.T) {
+	dir := t.TempDir()
+	l := NewLogger(Dir(dir), Filename("service.log"), FileMode(0o640))
+	_ = l.Log(log.LevelInfo, "msg", "before")
+	if err := os.Rename(filepath.Join(dir, "service.log"), filepath.Join(dir, "moved.log")); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+	_ = l.Log(log.LevelInfo, "msg", "after")
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := os.Stat(filepath.Join(dir, "service.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o640 {
+		t.Errorf("mode = %v, want 0640", info.Mode().Perm())
+	}
+	if b, _ := os.ReadFile(filepath.Join(dir, "service.log")); string(b) != "msg=after level=INFO\n" {
+		t.Errorf("got %q", b)
+	}
+}
+
+func TestTimeRotateWriterKeepsRegularFile(t *testing.T) {
+	dir := t.TempDir()
+	loc := time.FixedZone("CST", 8*3600)
+	fc := testingclock.NewFakePassiveClock(time.Date(2026, 10, 17, 12, 0, 0, 0, loc))
+	link := filepath.Join(dir, "service.log")
+	if err := os.WriteFile(link, []byte("size rotated\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// 16 日的压缩目标被目录占用, 压缩失败后仍继续处理 15 日
+	for _, name := range []string{"service-2026-10-15.log", "service-2026-10-16.log"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("old\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "service-2026-10-16.log.gz"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	w := NewTimeRotateWriter(dir, "service.log", Rotation_daily, loc, 0, 0, Compression_gzip, fc)
+	if _, err := w.Write([]byte("line\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.mill(); err == nil {
+		t.Error("mill did not report the failed compression")
+	}
+
+	if b, err := os.ReadFile(link); err != nil || string(b) != "size rotated\n" {
+		t.Errorf("regular file replaced: %q, %v", b, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "service-2026-10-15.log.gz")); err != nil {
+		t.Errorf("older backup not compressed: %v", err)
+	}
+}
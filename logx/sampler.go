@@ -0,0 +1,176 @@
+package logx
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/zhaoqiang0201/pkg/clock"
+)
+
+const (
+	samplerBuckets = 4096
+	// samplerLevels 覆盖 LevelDebug 到 LevelFatal
+	samplerLevels = int(log.LevelFatal-log.LevelDebug) + 1
+)
+
+// Sampler limits the volume of identical entries: within every tick the first
+// entries with the same level and message are logged, after that only every
+// thereafter-th. Entries without a message are never sampled.
+type Sampler struct {
+	clock      clock.Clock
+	tick       time.Duration
+	first      uint64
+	thereafter uint64
+	msgKey     string
+
+	counts     [samplerLevels][samplerBuckets]samplerCounter
+	suppressed atomic.Uint64
+}
+
+type samplerCounter struct {
+	resetAt atomic.Int64
+	count   atomic.Uint64
+}
+
+// NewSampler returns a Sampler logging the first entries per tick, then
+// every thereafter-th. With thereafter 0 the rest of the tick is dropped.
+// A nil c uses the clock of the Logger, see Clock, or the real clock.
+func NewSampler(c clock.Clock, tick time.Duration, first, thereafter int) *Sampler {
+	return &Sampler{
+		clock:      c,
+		tick:       tick,
+		first:      uint64(first),
+		thereafter: uint64(thereafter),
+		msgKey:     DefaultMessageKey,
+	}
+}
+
+// Allow reports whether an entry with level and keyvals should be logged,
+// and counts it as suppressed if not.
+func (s *Sampler) Allow(level log.Level, keyvals ...interface{}) bool {
+	msg, ok := s.message(keyvals)
+	if !ok || level < log.LevelDebug || level > log.LevelFatal {
+		return true
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(msg))
+	c := &s.counts[level-log.LevelDebug][h.Sum32()%samplerBuckets]
+
+	n := c.incCheckReset(s.clock.Now(), s.tick)
+	if n <= s.first || (s.thereafter > 0 && (n-s.first)%s.thereafter == 0) {
+		return true
+	}
+	s.suppressed.Add(1)
+	return false
+}
+
+func (s *Sampler) message(keyvals []interface{}) (string, bool) {
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		if keyvals[i] == s.msgKey {
+			if msg, ok := keyvals[i+1].(string); ok {
+				return msg, true
+			}
+			return fmt.Sprint(keyvals[i+1]), true
+		}
+	}
+	return "", false
+}
+
+// Suppressed returns the number of entries dropped so far.
+func (s *Sampler) Suppressed() uint64 {
+	return s.suppressed.Load()
+}
+
+// TakeSuppressed returns the number of entries dropped since the last call.
+func (s *Sampler) TakeSuppressed() uint64 {
+	return s.suppressed.Swap(0)
+}
+
+func (c *samplerCounter) incCheckReset(t time.Time, tick time.Duration) uint64 {
+	tn := t.UnixNano()
+	resetAt := c.resetAt.Load()
+	if resetAt > tn {
+		return c.count.Add(1)
+	}
+
+	c.count.Store(1)
+	if !c.resetAt.CompareAndSwap(resetAt, tn+tick.Nanoseconds()) {
+		// 其他 goroutine 已重置计数
+		return c.count.Add(1)
+	}
+	return 1
+}
+
+// Sampling makes the logger drop entries rejected by s. Every reportInterval
+// and on Close the number of dropped entries is logged at WARN, if any.
+func Sampling(s *Sampler, reportInterval time.Duration) Option {
+	return func(l *Logger) {
+		l.sampler = s
+		l.sampleReport = reportInterval
+	}
+}
+
+// NewSampledLogger returns a logger passing only the entries allowed by s
+// on to l.
+func NewSampledLogger(l log.Logger, s *Sampler) log.Logger {
+	if s.clock == nil {
+		s.clock = clock.RealClock{}
+	}
+	return &sampledLogger{logger: l, sampler: s}
+}
+
+type sampledLogger struct {
+	logger  log.Logger
+	sampler *Sampler
+}
+
+func (l *sampledLogger) Log(level log.Level, keyvals ...interface{}) error {
+	if !l.sampler.Allow(level, keyvals...) {
+		return nil
+	}
+	return l.logger.Log(level, keyvals...)
+}
+
+func (lx *Logger) reportSampling() {
+	lx.sampleStop = make(chan struct{})
+	lx.sampleDone = make(chan struct{})
+	ticker := lx.sampler.clock.NewTicker(lx.sampleReport)
+	go func() {
+		defer close(lx.sampleDone)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-lx.sampleStop:
+				lx.reportSuppressed()
+				return
+			case <-ticker.C():
+				lx.reportSuppressed()
+			}
+		}
+	}()
+}
+
+func (lx *Logger) reportSuppressed() {
+	n := lx.sampler.TakeSuppressed()
+	if n == 0 {
+		return
+	}
+	_ = lx.write(log.LevelWarn,
+		"ts", lx.Timestamp()(context.Background()),
+		lx.sampler.msgKey, "logx: entries suppressed by sampling",
+		"suppressed", n,
+	)
+}
+
+func (lx *Logger) stopSampling() {
+	if lx.sampleStop == nil {
+		return
+	}
+	close(lx.sampleStop)
+	<-lx.sampleDone
+	lx.sampleStop = nil
+}
@@ -0,0 +1,79 @@
+package logx
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	testingclock "github.com/zhaoqiang0201/pkg/clock/testing"
+)
+
+func TestSampling(t *testing.T) {
+	fc := testingclock.NewFakeClock(time.Now())
+	buf := &bytes.Buffer{}
+	l := NewLogger(Output(buf), Clock(fc), Sampling(NewSampler(fc, time.Second, 2, 3), time.Minute))
+
+	for i := 0; i < 10; i++ {
+		_ = l.Log(log.LevelInfo, "msg", "hot")
+	}
+	_ = l.Log(log.LevelWarn, "msg", "hot")
+	_ = l.Log(log.LevelInfo, "no", "message")
+	fc.Step(time.Second)
+	_ = l.Log(log.LevelInfo, "msg", "hot")
+
+	if got := strings.Count(buf.String(), "msg=hot level=INFO"); got != 5 {
+		t.Fatalf("got %d sampled INFO lines, want 5:\n%s", got, buf.String())
+	}
+	if got := l.sampler.Suppressed(); got != 6 {
+		t.Fatalf("Suppressed() = %d, want 6", got)
+	}
+
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "suppressed=6") {
+		t.Fatalf("missing suppressed report:\n%s", buf.String())
+	}
+}
+
+func TestConfSamplingUsesLoggerClock(t *testing.T) {
+	fc := testingclock.NewFakeClock(time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC))
+	buf := &bytes.Buffer{}
+	l := NewConfLogger(&LogxConf{
+		Encoding:   Encode_json,
+		Sampling:   &LogxSampling{First: 1, ReportIntervalS: 60},
+		FieldNames: &LogxFieldNames{Message: "text"},
+	}, Output(buf), Clock(fc), TimeUTC(true))
+
+	if !fc.HasWaiters() {
+		t.Fatal("report ticker not on the logger clock")
+	}
+	for i := 0; i < 3; i++ {
+		_ = l.Log(log.LevelInfo, "msg", "hot")
+	}
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `"text":"logx: entries suppressed by sampling"`) ||
+		!strings.Contains(buf.String(), `"suppressed":2`) ||
+		!strings.Contains(buf.String(), "2026-10-19T10:00:00") {
+		t.Fatalf("unexpected report:\n%s", buf.String())
+	}
+}
+
+func TestConfSamplingDefaults(t *testing.T) {
+	fc := testingclock.NewFakeClock(time.Now())
+	buf := &bytes.Buffer{}
+	l := NewConfLogger(&LogxConf{Sampling: &LogxSampling{TickMs: 1000}}, Output(buf), Clock(fc))
+	defer l.Close()
+
+	for i := 0; i < 300; i++ {
+		_ = l.Log(log.LevelInfo, "msg", "hot")
+	}
+	// 默认 first 100, thereafter 100: 前 100 条, 之后第 200 和 300 条
+	if n := strings.Count(buf.String(), "msg=hot"); n != 102 {
+		t.Fatalf("got %d entries, want 102", n)
+	}
+}
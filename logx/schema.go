@@ -0,0 +1,543 @@
+package logx
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// The standard keys added by WithService, mapped to the fields of each
+// schema by the logfmt, gelf, ecs and otlp encodings.
+const (
+	keyTimestamp      = DefaultTimestampField
+	keyCaller         = "caller"
+	keyServiceID      = "service.id"
+	keyServiceName    = "service.name"
+	keyServiceVersion = "service.version"
+	keyTraceID        = "trace.id"
+	keySpanID         = "span.id"
+)
+
+const ecsVersion = "8.11.0"
+
+// entryFields splits keyvals into the standard fields and the rest.
+type entryFields struct {
+	ts      interface{}
+	caller  interface{}
+	msg     interface{}
+	service [3]interface{} // id, name, version
+	traceID string
+	spanID  string
+	rest    []interface{}
+}
+
+func splitEntry(keyvals []interface{}) entryFields {
+	if (len(keyvals) & 1) == 1 {
+		keyvals = append(keyvals, "KEYVALS UNPAIRED")
+	}
+	var f entryFields
+	for i := 0; i < len(keyvals); i += 2 {
+		v := keyvals[i+1]
+		switch keyvals[i] {
+		case keyTimestamp:
+			f.ts = v
+		case keyCaller:
+			f.caller = v
+		case DefaultMessageKey:
+			f.msg = v
+		case keyServiceID:
+			f.service[0] = v
+		case keyServiceName:
+			f.service[1] = v
+		case keyServiceVersion:
+			f.service[2] = v
+		case keyTraceID:
+			f.traceID = fmt.Sprint(v)
+		case keySpanID:
+			f.spanID = fmt.Sprint(v)
+		default:
+			if f.rest == nil {
+				f.rest = make([]interface{}, 0, len(keyvals)-i)
+			}
+			f.rest = append(f.rest, keyvals[i], v)
+		}
+	}
+	return f
+}
+
+// entryTime returns the time of the ts field, in any of the formats of
+// Timestamp.
+func entryTime(ts interface{}) (time.Time, bool) {
+	switch v := ts.(type) {
+	case timestamp:
+		return v.t, true
+	case time.Time:
+		return v, true
+	case int64:
+		return time.UnixMilli(v), true
+	case string:
+		t, err := time.Parse(time.RFC3339Nano, v)
+		return t, err == nil
+	}
+	return time.Time{}, false
+}
+
+// splitCaller splits "file:line".
+func splitCaller(caller interface{}) (string, int) {
+	s := fmt.Sprint(caller)
+	i := strings.LastIndexByte(s, ':')
+	if i < 0 {
+		return s, 0
+	}
+	line, err := strconv.Atoi(s[i+1:])
+	if err != nil {
+		return s, 0
+	}
+	return s[:i], line
+}
+
+func keyString(key interface{}) string {
+	if s, ok := key.(string); ok {
+		return s
+	}
+	return fmt.Sprint(key)
+}
+
+// schemaLogger encodes entries with an encode func into a pooled buffer.
+type schemaLogger struct {
+	entryWriter
+	encode func(buf []byte, level log.Level, f *entryFields) []byte
+}
+
+func (l *schemaLogger) Log(level log.Level, keyvals ...interface{}) error {
+	if len(keyvals) == 0 {
+		return nil
+	}
+	f := splitEntry(keyvals)
+	bp := getBuffer()
+	buf := l.encode((*bp)[:0], level, &f)
+	return l.writeEntry(level, bp, append(buf, '\n'))
+}
+
+// NewLogfmtLogger returns an encoder writing strict logfmt: ts, level,
+// caller and msg first, the level in lower case, then the other fields in
+// order.
+func NewLogfmtLogger(w io.Writer) log.Logger {
+	return &schemaLogger{entryWriter: newEntryWriter(w), encode: encodeLogfmt}
+}
+
+func encodeLogfmt(buf []byte, level log.Level, f *entryFields) []byte {
+	pair := func(key string, v interface{}) {
+		if len(buf) > 0 {
+			buf = append(buf, ' ')
+		}
+		buf = appendLogfmtKey(buf, key)
+		buf = append(buf, '=')
+		buf = appendLogfmtValue(buf, v)
+	}
+	if f.ts != nil {
+		pair(keyTimestamp, f.ts)
+	}
+	pair("level", strings.ToLower(level.String()))
+	if f.caller != nil {
+		pair(keyCaller, f.caller)
+	}
+	if f.msg != nil {
+		pair(DefaultMessageKey, f.msg)
+	}
+	for i, key := range [3]string{keyServiceID, keyServiceName, keyServiceVersion} {
+		if f.service[i] != nil {
+			pair(key, f.service[i])
+		}
+	}
+	if f.traceID != "" {
+		pair(keyTraceID, f.traceID)
+	}
+	if f.spanID != "" {
+		pair(keySpanID, f.spanID)
+	}
+	for i := 0; i < len(f.rest); i += 2 {
+		pair(keyString(f.rest[i]), f.rest[i+1])
+	}
+	return buf
+}
+
+// NewGELFLogger returns an encoder writing Graylog GELF 1.1 messages, one per
+// line. The standard fields become _caller, _service_id, _service_name,
+// _service_version, _trace_id and _span_id, the others are prefixed with _.
+func NewGELFLogger(w io.Writer) log.Logger {
+	host, _ := os.Hostname()
+	return &schemaLogger{
+		entryWriter: newEntryWriter(w),
+		encode: func(buf []byte, level log.Level, f *entryFields) []byte {
+			return encodeGELF(buf, host, level, f)
+		},
+	}
+}
+
+func encodeGELF(buf []byte, host string, level log.Level, f *entryFields) []byte {
+	buf = append(buf, `{"version":"1.1","host":`...)
+	buf = appendJSONString(buf, host)
+	buf = append(buf, `,"short_message":`...)
+	if f.msg != nil {
+		buf = appendJSONString(buf, stringify(f.msg))
+	} else {
+		buf = append(buf, `"-"`...)
+	}
+	if t, ok := entryTime(f.ts); ok {
+		buf = append(buf, `,"timestamp":`...)
+		buf = strconv.AppendFloat(buf, float64(t.UnixMilli())/1e3, 'f', 3, 64)
+	}
+	buf = append(buf, `,"level":`...)
+	buf = strconv.AppendInt(buf, int64(syslogLevel(level)), 10)
+
+	field := func(key string, v interface{}) {
+		buf = append(buf, ',')
+		buf = appendJSONString(buf, gelfKey(key))
+		buf = append(buf, ':')
+		buf = appendGELFValue(buf, v)
+	}
+	if f.caller != nil {
+		field("caller", f.caller)
+	}
+	for i, key := range [3]string{"service_id", "service_name", "service_version"} {
+		if f.service[i] != nil {
+			field(key, f.service[i])
+		}
+	}
+	if f.traceID != "" {
+		field("trace_id", f.traceID)
+	}
+	if f.spanID != "" {
+		field("span_id", f.spanID)
+	}
+	for i := 0; i < len(f.rest); i += 2 {
+		field(keyString(f.rest[i]), f.rest[i+1])
+	}
+	return append(buf, '}')
+}
+
+// gelfKey returns the additional field name for key: prefixed with _, with
+// the characters other than [\w.-] replaced by _. _id is reserved.
+func gelfKey(key string) string {
+	b := make([]byte, 0, len(key)+1)
+	b = append(b, '_')
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '.' || c == '-') {
+			c = '_'
+		}
+		b = append(b, c)
+	}
+	if string(b) == "_id" {
+		return "_id_"
+	}
+	return string(b)
+}
+
+// appendGELFValue appends v as a number or a string, the only types GELF
+// allows for additional fields.
+func appendGELFValue(buf []byte, v interface{}) []byte {
+	switch v.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return appendJSONValue(buf, v)
+	}
+	return appendJSONString(buf, stringify(v))
+}
+
+// syslogLevel maps level to the syslog severity used by GELF.
+func syslogLevel(level log.Level) int {
+	switch level {
+	case log.LevelDebug:
+		return 7
+	case log.LevelWarn:
+		return 4
+	case log.LevelError:
+		return 3
+	case log.LevelFatal:
+		return 2
+	default:
+		return 6
+	}
+}
+
+// NewECSLogger returns an encoder writing JSON with Elastic Common Schema
+// field names: @timestamp, log.level, message, log.origin.file.name and
+// log.origin.file.line, service.*, trace.id and span.id. An "error" field is
+// written as error.message, fields named like the ones written by the
+// encoder itself, e.g. "message", are prefixed with labels.
+func NewECSLogger(w io.Writer) log.Logger {
+	return &schemaLogger{entryWriter: newEntryWriter(w), encode: encodeECS}
+}
+
+func encodeECS(buf []byte, level log.Level, f *entryFields) []byte {
+	buf = append(buf, '{')
+	field := func(key string, v interface{}) {
+		if len(buf) > 1 {
+			buf = append(buf, ',')
+		}
+		buf = appendJSONString(buf, key)
+		buf = append(buf, ':')
+		buf = appendJSONValue(buf, v)
+	}
+	if t, ok := entryTime(f.ts); ok {
+		field("@timestamp", t.UTC().Format("2006-01-02T15:04:05.000Z07:00"))
+	} else if f.ts != nil {
+		field("@timestamp", f.ts)
+	}
+	field("log.level", strings.ToLower(level.String()))
+	if f.msg != nil {
+		field("message", stringify(f.msg))
+	}
+	field("ecs.version", ecsVersion)
+	if f.caller != nil {
+		file, line := splitCaller(f.caller)
+		field("log.origin.file.name", file)
+		if line > 0 {
+			field("log.origin.file.line", line)
+		}
+	}
+	for i, key := range [3]string{keyServiceID, keyServiceName, keyServiceVersion} {
+		if f.service[i] != nil {
+			field(key, f.service[i])
+		}
+	}
+	if f.traceID != "" {
+		field(keyTraceID, f.traceID)
+	}
+	if f.spanID != "" {
+		field(keySpanID, f.spanID)
+	}
+	for i := 0; i < len(f.rest); i += 2 {
+		key := keyString(f.rest[i])
+		switch key {
+		case "error":
+			key = "error.message"
+		case "@timestamp", "log.level", "message", "ecs.version", "log.origin.file.name", "log.origin.file.line":
+			key = "labels." + key
+		}
+		field(key, f.rest[i+1])
+	}
+	return append(buf, '}')
+}
+
+// NewOTLPLogger returns an encoder writing log records of the OpenTelemetry
+// log data model in OTLP/JSON encoding, one ExportLogsServiceRequest per
+// line as read by the otlpjsonfile receiver of the collector. service.*
+// become resource attributes, caller the code.filepath and code.lineno
+// attributes of the record.
+func NewOTLPLogger(w io.Writer) log.Logger {
+	return &schemaLogger{entryWriter: newEntryWriter(w), encode: encodeOTLP}
+}
+
+func encodeOTLP(buf []byte, level log.Level, f *entryFields) []byte {
+	first := true
+	attr := func(key string, v interface{}) {
+		if !first {
+			buf = append(buf, ',')
+		}
+		first = false
+		buf = append(buf, `{"key":`...)
+		buf = appendJSONString(buf, key)
+		buf = append(buf, `,"value":`...)
+		buf = appendOTLPValue(buf, v)
+		buf = append(buf, '}')
+	}
+
+	buf = append(buf, `{"resourceLogs":[{"resource":{"attributes":[`...)
+	for i, key := range [3]string{"service.instance.id", "service.name", "service.version"} {
+		if f.service[i] != nil {
+			attr(key, f.service[i])
+		}
+	}
+	buf = append(buf, `]},"scopeLogs":[{"logRecords":[{`...)
+	if t, ok := entryTime(f.ts); ok {
+		buf = append(buf, `"timeUnixNano":"`...)
+		buf = strconv.AppendInt(buf, t.UnixNano(), 10)
+		buf = append(buf, `",`...)
+	}
+	buf = append(buf, `"severityNumber":`...)
+	buf = strconv.AppendInt(buf, int64(otlpSeverity(level)), 10)
+	buf = append(buf, `,"severityText":`...)
+	buf = appendJSONString(buf, level.String())
+	if f.msg != nil {
+		buf = append(buf, `,"body":`...)
+		buf = appendOTLPValue(buf, f.msg)
+	}
+	if f.traceID != "" {
+		buf = append(buf, `,"traceId":`...)
+		buf = appendJSONString(buf, f.traceID)
+	}
+	if f.spanID != "" {
+		buf = append(buf, `,"spanId":`...)
+		buf = appendJSONString(buf, f.spanID)
+	}
+
+	buf = append(buf, `,"attributes":[`...)
+	first = true
+	if f.caller != nil {
+		file, line := splitCaller(f.caller)
+		attr("code.filepath", file)
+		if line > 0 {
+			attr("code.lineno", line)
+		}
+	}
+	for i := 0; i < len(f.rest); i += 2 {
+		attr(keyString(f.rest[i]), f.rest[i+1])
+	}
+	return append(buf, "]}]}]}]}"...)
+}
+
+// otlpSeverity maps level to the first SeverityNumber of its range.
+func otlpSeverity(level log.Level) int {
+	switch level {
+	case log.LevelDebug:
+		return 5
+	case log.LevelWarn:
+		return 13
+	case log.LevelError:
+		return 17
+	case log.LevelFatal:
+		return 21
+	default:
+		return 9
+	}
+}
+
+// appendOTLPValue appends v as an OTLP/JSON AnyValue. 64 bit integers are
+// strings, as in the protobuf JSON mapping.
+func appendOTLPValue(buf []byte, v interface{}) []byte {
+	switch v := v.(type) {
+	case bool:
+		buf = append(buf, `{"boolValue":`...)
+		buf = strconv.AppendBool(buf, v)
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32:
+		buf = append(buf, `{"intValue":"`...)
+		buf = appendJSONValue(buf, v)
+		buf = append(buf, '"')
+	case float32, float64:
+		buf = append(buf, `{"doubleValue":`...)
+		buf = appendJSONValue(buf, v)
+	default:
+		buf = append(buf, `{"stringValue":`...)
+		buf = appendJSONString(buf, stringify(v))
+	}
+	return append(buf, '}')
+}
+
+// appendJSONValue appends v as JSON, with fast paths for the common types
+// and encoding/json for the others.
+func appendJSONValue(buf []byte, v interface{}) []byte {
+	switch v := v.(type) {
+	case nil:
+		return append(buf, "null"...)
+	case string:
+		return appendJSONString(buf, v)
+	case []byte:
+		return appendJSONString(buf, string(v))
+	case bool:
+		return strconv.AppendBool(buf, v)
+	case int:
+		return strconv.AppendInt(buf, int64(v), 10)
+	case int8:
+		return strconv.AppendInt(buf, int64(v), 10)
+	case int16:
+		return strconv.AppendInt(buf, int64(v), 10)
+	case int32:
+		return strconv.AppendInt(buf, int64(v), 10)
+	case int64:
+		return strconv.AppendInt(buf, v, 10)
+	case uint:
+		return strconv.AppendUint(buf, uint64(v), 10)
+	case uint8:
+		return strconv.AppendUint(buf, uint64(v), 10)
+	case uint16:
+		return strconv.AppendUint(buf, uint64(v), 10)
+	case uint32:
+		return strconv.AppendUint(buf, uint64(v), 10)
+	case uint64:
+		return strconv.AppendUint(buf, v, 10)
+	case float32:
+		return appendJSONFloat(buf, float64(v), 32)
+	case float64:
+		return appendJSONFloat(buf, v, 64)
+	case time.Time:
+		buf = append(buf, '"')
+		buf = v.AppendFormat(buf, time.RFC3339Nano)
+		return append(buf, '"')
+	case time.Duration:
+		return appendJSONString(buf, v.String())
+	case error:
+		// stringify 经 fmt 输出, nil 指针为 <nil>
+		return appendJSONString(buf, stringify(v))
+	case json.Marshaler:
+		// 优先使用 MarshalJSON
+	case fmt.Stringer:
+		return appendJSONString(buf, stringify(v))
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return appendJSONString(buf, fmt.Sprint(v))
+	}
+	return append(buf, b...)
+}
+
+func appendJSONFloat(buf []byte, f float64, bits int) []byte {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return appendJSONString(buf, strconv.FormatFloat(f, 'g', -1, bits))
+	}
+	return strconv.AppendFloat(buf, f, 'g', -1, bits)
+}
+
+const hexDigits = "0123456789abcdef"
+
+// appendJSONString appends s as a JSON string. Invalid UTF-8 is replaced by
+// U+FFFD.
+func appendJSONString(buf []byte, s string) []byte {
+	buf = append(buf, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c < utf8.RuneSelf {
+			if c >= ' ' && c != '"' && c != '\\' {
+				i++
+				continue
+			}
+			buf = append(buf, s[start:i]...)
+			switch c {
+			case '"', '\\':
+				buf = append(buf, '\\', c)
+			case '\n':
+				buf = append(buf, '\\', 'n')
+			case '\r':
+				buf = append(buf, '\\', 'r')
+			case '\t':
+				buf = append(buf, '\\', 't')
+			default:
+				buf = append(buf, '\\', 'u', '0', '0', hexDigits[c>>4], hexDigits[c&0xf])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			buf = append(buf, s[start:i]...)
+			buf = append(buf, "\ufffd"...)
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	buf = append(buf, s[start:]...)
+	return append(buf, '"')
+}
@@ -0,0 +1,154 @@
+package logx
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	testingclock "github.com/zhaoqiang0201/pkg/clock/testing"
+)
+
+var schemaEntry = []interface{}{
+	"ts", "2026-10-19T10:00:00.123+08:00",
+	"caller", "app/service/user.go:42",
+	"service.id", "host-1",
+	"service.name", "user",
+	"service.version", "v1.2.0",
+	"trace.id", "4bf92f3577b34da6a3ce929d0e0e4736",
+	"span.id", "00f067aa0ba902b7",
+	"msg", "create failed",
+	"user id", 7,
+	"error", errors.New("duplicate"),
+}
+
+func TestLogfmtLogger(t *testing.T) {
+	var buf bytes.Buffer
+	_ = NewLogfmtLogger(&buf).Log(log.LevelWarn, schemaEntry...)
+	want := `ts=2026-10-19T10:00:00.123+08:00 level=warn caller=app/service/user.go:42 msg="create failed" ` +
+		`service.id=host-1 service.name=user service.version=v1.2.0 trace.id=4bf92f3577b34da6a3ce929d0e0e4736 ` +
+		`span.id=00f067aa0ba902b7 user_id=7 error=duplicate` + "\n"
+	if buf.String() != want {
+		t.Errorf("got  %s\nwant %s", buf.String(), want)
+	}
+}
+
+func TestJSONSchemaLoggers(t *testing.T) {
+	tests := []struct {
+		encoding string
+		want     map[string]interface{}
+	}{
+		{encoding_gelf, map[string]interface{}{
+			"version": "1.1", "short_message": "create failed", "timestamp": 1792375200.123, "level": float64(4),
+			"_caller": "app/service/user.go:42", "_service_name": "user", "_trace_id": "4bf92f3577b34da6a3ce929d0e0e4736",
+			"_user_id": float64(7), "_error": "duplicate",
+		}},
+		{encoding_ecs, map[string]interface{}{
+			"@timestamp": "2026-10-19T02:00:00.123Z", "log.level": "warn", "message": "create failed", "ecs.version": ecsVersion,
+			"log.origin.file.name": "app/service/user.go", "log.origin.file.line": float64(42),
+			"service.id": "host-1", "service.version": "v1.2.0", "span.id": "00f067aa0ba902b7",
+			"user id": float64(7), "error.message": "duplicate",
+		}},
+		{encoding_otlp, map[string]interface{}{
+			"timeUnixNano": "1792375200123000000", "severityNumber": float64(13), "severityText": "WARN",
+			"traceId": "4bf92f3577b34da6a3ce929d0e0e4736", "spanId": "00f067aa0ba902b7",
+		}},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		l := NewLogger(Output(&buf), Encoding(tt.encoding))
+		_ = l.Log(log.LevelWarn, schemaEntry...)
+		if !strings.HasSuffix(buf.String(), "}\n") || strings.Count(buf.String(), "\n") != 1 {
+			t.Errorf("%s: not one JSON line: %q", tt.encoding, buf.String())
+		}
+		var entry map[string]interface{}
+		if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+			t.Fatalf("%s: %v: %s", tt.encoding, err, buf.String())
+		}
+		if tt.encoding == encoding_otlp {
+			entry = otlpRecord(t, entry)
+		}
+		for k, v := range tt.want {
+			if entry[k] != v {
+				t.Errorf("%s: %s = %v, want %v", tt.encoding, k, entry[k], v)
+			}
+		}
+	}
+}
+
+func TestOTLPAttributes(t *testing.T) {
+	var buf bytes.Buffer
+	_ = NewOTLPLogger(&buf).Log(log.LevelInfo, schemaEntry...)
+	for _, want := range []string{
+		`"body":{"stringValue":"create failed"}`,
+		`{"resourceLogs":[{"resource":{"attributes":[{"key":"service.instance.id","value":{"stringValue":"host-1"}},` +
+			`{"key":"service.name","value":{"stringValue":"user"}},{"key":"service.version","value":{"stringValue":"v1.2.0"}}]},` +
+			`"scopeLogs":[{"logRecords":[{"timeUnixNano":`,
+		`{"key":"code.filepath","value":{"stringValue":"app/service/user.go"}},{"key":"code.lineno","value":{"intValue":"42"}}`,
+		`{"key":"user id","value":{"intValue":"7"}}`,
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("missing %s in %s", want, buf.String())
+		}
+	}
+}
+
+// otlpRecord returns the only log record of an ExportLogsServiceRequest.
+func otlpRecord(t *testing.T, req map[string]interface{}) map[string]interface{} {
+	t.Helper()
+	var resp struct {
+		ResourceLogs []struct {
+			ScopeLogs []struct {
+				LogRecords []map[string]interface{} `json:"logRecords"`
+			} `json:"scopeLogs"`
+		} `json:"resourceLogs"`
+	}
+	b, _ := json.Marshal(req)
+	if err := json.Unmarshal(b, &resp); err != nil || len(resp.ResourceLogs) != 1 ||
+		len(resp.ResourceLogs[0].ScopeLogs) != 1 || len(resp.ResourceLogs[0].ScopeLogs[0].LogRecords) != 1 {
+		t.Fatalf("not one log record: %s", b)
+	}
+	return resp.ResourceLogs[0].ScopeLogs[0].LogRecords[0]
+}
+
+func TestSchemaTimePrecision(t *testing.T) {
+	fc := testingclock.NewFakePassiveClock(time.Date(2026, 10, 19, 10, 0, 0, 123456789, time.UTC))
+	for encoding, want := range map[Encode]string{
+		Encode_otlp: `"timeUnixNano":"1792404000123456789"`,
+		Encode_ecs:  `"@timestamp":"2026-10-19T10:00:00.123Z"`,
+		Encode_gelf: `"timestamp":1792404000.123`,
+	} {
+		var buf bytes.Buffer
+		l := SetUpLog("id", "name", "v1", &LogxConf{Encoding: encoding}, Clock(fc), Output(&buf))
+		_ = l.Log(log.LevelInfo, "msg", "hello")
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("%v: got %s, want %s", encoding, buf.String(), want)
+		}
+	}
+}
+
+func TestECSMessageField(t *testing.T) {
+	var buf bytes.Buffer
+	_ = NewECSLogger(&buf).Log(log.LevelInfo, "msg", "sent", "message", "hello")
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Count(buf.String(), `"message"`) != 1 || entry["message"] != "sent" || entry["labels.message"] != "hello" {
+		t.Errorf("message field collides: %s", buf.String())
+	}
+}
+
+func TestSchemaLoggersTypedNil(t *testing.T) {
+	for _, encoding := range []string{encoding_logfmt, encoding_gelf, encoding_ecs, encoding_otlp} {
+		var buf bytes.Buffer
+		l := NewLogger(Output(&buf), Encoding(encoding))
+		_ = l.Log(log.LevelError, "msg", "failed", "err", (*stackError)(nil), "s", (*nilStringer)(nil))
+		if strings.Count(buf.String(), "<nil>") != 2 {
+			t.Errorf("%s: got %s", encoding, buf.String())
+		}
+	}
+}
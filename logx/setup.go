@@ -2,31 +2,71 @@ package logx
 
 import (
 	"context"
+	"encoding/base64"
+	"fmt"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/go-kratos/kratos/v2/middleware/tracing"
+	"os"
 	"runtime"
 	"strconv"
 	"strings"
+	"syscall"
+	"time"
 )
 
-func SetUpLog(serviceId string, serviceName string, serviceVersion string, c *LogxConf) log.Logger {
+// SetUpLog builds the service logger from c. opts are applied after the
+// options derived from c, e.g. Clock to make the ts field deterministic.
+// Use NewConfLogger and WithService instead to be able to Close the logger.
+func SetUpLog(serviceId string, serviceName string, serviceVersion string, c *LogxConf, opts ...Option) log.Logger {
+	return WithService(NewConfLogger(c, opts...), serviceId, serviceName, serviceVersion)
+}
+
+// NewConfLogger builds a Logger from c. opts are applied after the options
+// derived from c.
+func NewConfLogger(c *LogxConf, opts ...Option) *Logger {
 	filename := "service.log"
 	if c.FileName != "" {
 		filename = c.FileName
 	}
+	confOpts := []Option{
+		Dir(c.PathDir),
+		Filename(filename),
+		MaxSize(int(c.MaxSize)),
+		KeepDay(int(c.KeepDays)),
+		MaxBackup(int(c.MaxBackups)),
+		// 不使用 String(), 默认 Helper 在 init() 中创建时 proto 描述符尚未初始化
+		Encoding(Encode_name[int32(c.Encoding)]),
+		Level(LogLevel_name[int32(c.Level)]),
+		TimeFormat(c.TimeFormat),
+		TimeUTC(c.TimeUtc),
+		ModuleLevels(moduleLevelNames(c.ModuleLevels)),
+		RotateBy(c.Rotation),
+		RotateLocation(rotateLocation(c.RotationTimezone)),
+		Compress(c.Compression),
+		LocalTime(c.LocalTime),
+		FileMode(fileMode(c.FileMode)),
+		//FilterKey("args"),
+		//logx.EntryptionFn(logEntryption),
+	}
+	confOpts = append(confOpts, sinkOptions(c.Sinks)...)
+	confOpts = append(confOpts, asyncOptions(c.Async)...)
+	confOpts = append(confOpts, signalOptions(c)...)
+	confOpts = append(confOpts, samplingOptions(c.Sampling)...)
+	confOpts = append(confOpts, redactOptions(c.Redact)...)
+	if c.ErrorStack {
+		confOpts = append(confOpts, ErrorStack(log.LevelError))
+	}
+	if f := c.FieldNames; f != nil {
+		confOpts = append(confOpts, FieldNames(f.Timestamp, f.Level, f.Message))
+	}
+	return NewLogger(append(confOpts, opts...)...)
+}
+
+// WithService adds the ts, caller, service and tracing fields to logger.
+func WithService(logger *Logger, serviceId string, serviceName string, serviceVersion string) log.Logger {
 	l := log.With(
-		NewLogger(
-			Dir(c.PathDir),
-			Filename(filename),
-			MaxSize(int(c.MaxSize)),
-			KeepDay(int(c.KeepDays)),
-			MaxBackup(int(c.MaxBackups)),
-			Encoding(c.Encoding.String()),
-			Level(c.Level.String()),
-			//FilterKey("args"),
-			//logx.EntryptionFn(logEntryption),
-		),
-		"ts", log.DefaultTimestamp,
+		logger,
+		"ts", logger.Timestamp(),
 		//"caller", log.DefaultCaller,
 		"caller", Caller(4),
 		"service.id", serviceId,
@@ -39,21 +79,143 @@ func SetUpLog(serviceId string, serviceName string, serviceVersion string, c *Lo
 	return l
 }
 
+func moduleLevelNames(levels map[string]LogLevel) map[string]string {
+	names := make(map[string]string, len(levels))
+	for module, level := range levels {
+		names[module] = LogLevel_name[int32(level)]
+	}
+	return names
+}
+
+func sinkOptions(sinks []*LogxSink) []Option {
+	opts := make([]Option, 0, len(sinks))
+	for _, s := range sinks {
+		opts = append(opts, Sink(s.Target, Encode_name[int32(s.Encoding)], LogLevel_name[int32(s.Level)]))
+	}
+	return opts
+}
+
+func asyncOptions(c *LogxAsync) []Option {
+	if c == nil {
+		return nil
+	}
+	return []Option{Async(
+		AsyncBufferSize(int(c.BufferSize)),
+		AsyncDropPolicy(c.DropPolicy),
+		AsyncFlushInterval(time.Duration(c.FlushIntervalMs)*time.Millisecond),
+	)}
+}
+
+func rotateLocation(name string) *time.Location {
+	if name == "" {
+		return nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "logx: unknown rotation_timezone %q, using local time: %v\n", name, err)
+		return nil
+	}
+	return loc
+}
+
+func fileMode(s string) os.FileMode {
+	if s == "" {
+		return 0
+	}
+	mode, err := strconv.ParseUint(s, 8, 32)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "logx: invalid file_mode %q: %v\n", s, err)
+		return 0
+	}
+	return os.FileMode(mode).Perm()
+}
+
+func signalOptions(c *LogxConf) []Option {
+	if !c.RotateOnSighup {
+		return nil
+	}
+	return []Option{RotateOnSignal(syscall.SIGHUP)}
+}
+
+func samplingOptions(c *LogxSampling) []Option {
+	if c == nil {
+		return nil
+	}
+	tick := time.Duration(c.TickMs) * time.Millisecond
+	if tick <= 0 {
+		tick = time.Second
+	}
+	report := time.Duration(c.ReportIntervalS) * time.Second
+	if report <= 0 {
+		report = time.Minute
+	}
+	first, thereafter := int(c.First), int(c.Thereafter)
+	if first == 0 && thereafter == 0 {
+		// 只配置了 tick_ms 等时不应丢弃所有重复日志, 与 zap 的默认值一致
+		first, thereafter = 100, 100
+	}
+	return []Option{Sampling(NewSampler(nil, tick, first, thereafter), report)}
+}
+
+// redactOptions fails closed: if the key or a rule is invalid, the fields
+// the rules would redact are masked instead, see failClosedRedactor.
+func redactOptions(c *LogxRedact) []Option {
+	if c == nil || len(c.Rules) == 0 {
+		return nil
+	}
+	opts := []RedactOption{RedactSalt([]byte(c.HashSalt))}
+	if enc, err := redactEncryptor(c); err != nil {
+		fmt.Fprintf(os.Stderr, "%v, encrypted fields are masked\n", err)
+	} else if enc != nil {
+		opts = append(opts, RedactEncryptor(enc))
+	}
+	r, err := NewRedactor(c.Rules, opts...)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v, matched fields are masked\n", err)
+		r = failClosedRedactor(c.Rules, opts...)
+	}
+	return []Option{Redact(r)}
+}
+
+func redactEncryptor(c *LogxRedact) (Encryptor, error) {
+	switch {
+	case c.AesKeyFile != "":
+		return LoadKeyring(c.AesKeyFile)
+	case c.AesKey != "":
+		key, err := base64.StdEncoding.DecodeString(c.AesKey)
+		if err != nil {
+			return nil, fmt.Errorf("logx: invalid redact aes_key: %w", err)
+		}
+		k, err := NewAESEncryptor(key)
+		if err != nil {
+			return nil, err
+		}
+		return k, nil
+	}
+	return nil, nil
+}
+
 func Caller(depth int) log.Valuer {
 	return func(context.Context) interface{} {
 		_, file, line, _ := runtime.Caller(depth)
 		//fmt.Println(file)
-		idx1 := strings.LastIndex(file, "vendor")
-		if idx1 == -1 {
-			idx := strings.LastIndexByte(file, '/')
-			if idx == -1 {
-				return file[idx+1:] + ":" + strconv.Itoa(line)
-			}
-			idx = strings.LastIndexByte(file[:idx], '/')
-			idx = strings.LastIndexByte(file[:idx], '/')
-			idx = strings.LastIndexByte(file[:idx], '/')
+		return shortCaller(file, line)
+	}
+}
+
+// shortCaller formats file:line keeping the last directories of file, or
+// the path below vendor.
+func shortCaller(file string, line int) string {
+	idx1 := strings.LastIndex(file, "vendor")
+	if idx1 == -1 {
+		idx := strings.LastIndexByte(file, '/')
+		if idx == -1 {
 			return file[idx+1:] + ":" + strconv.Itoa(line)
 		}
-		return strings.TrimPrefix(file[idx1:], "vendor/") + ":" + strconv.Itoa(line)
+		idx = strings.LastIndexByte(file[:idx], '/')
+		idx = strings.LastIndexByte(file[:idx], '/')
+		idx = strings.LastIndexByte(file[:idx], '/')
+		return file[idx+1:] + ":" + strconv.Itoa(line)
 	}
+	return strings.TrimPrefix(file[idx1:], "vendor/") + ":" + strconv.Itoa(line)
 }
@@ -0,0 +1,86 @@
+package logx
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	testingclock "github.com/zhaoqiang0201/pkg/clock/testing"
+)
+
+func TestSetUpLogTimestamp(t *testing.T) {
+	fc := testingclock.NewFakePassiveClock(time.Date(2024, 7, 1, 8, 30, 0, 123000000, time.FixedZone("CST", 8*3600)))
+	tests := []struct {
+		name string
+		conf *LogxConf
+		want string
+	}{
+		{
+			name: "plain utc",
+			conf: &LogxConf{Encoding: Encode_plain, TimeUtc: true},
+			want: "ts=2024-07-01T00:30:00Z level=INFO",
+		},
+		{
+			name: "plain rfc3339nano",
+			conf: &LogxConf{Encoding: Encode_plain, TimeFormat: TimeFormatRFC3339Nano},
+			want: "ts=2024-07-01T08:30:00.123+08:00 level=INFO",
+		},
+		{
+			name: "json unix milli",
+			conf: &LogxConf{Encoding: Encode_json, TimeFormat: TimeFormatUnixMilli},
+			want: `"ts":1719793800123`,
+		},
+		{
+			name: "json layout",
+			conf: &LogxConf{Encoding: Encode_json, TimeFormat: "2006-01-02 15:04:05", TimeUtc: true},
+			want: `"ts":"2024-07-01 00:30:00"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			l := SetUpLog("id", "name", "v1", tt.conf, Clock(fc), Output(buf))
+			_ = l.Log(log.LevelInfo, "msg", "hello")
+			if !bytes.Contains(buf.Bytes(), []byte(tt.want)) {
+				t.Fatalf("got %q, want it to contain %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestLoggerSinks(t *testing.T) {
+	all, errs := &bytes.Buffer{}, &bytes.Buffer{}
+	l := NewLogger(
+		Level("DEBUG"),
+		SinkWriter(all, encoding_json, "DEBUG"),
+		SinkWriter(errs, encoding_plain, "ERROR"),
+	)
+	_ = l.Log(log.LevelInfo, "msg", "info")
+	_ = l.Log(log.LevelError, "msg", "error")
+
+	if got := bytes.Count(all.Bytes(), []byte("\n")); got != 2 {
+		t.Fatalf("json sink got %d lines: %q", got, all.String())
+	}
+	if errs.String() != "msg=error level=ERROR\n" {
+		t.Fatalf("error sink got %q", errs.String())
+	}
+}
+
+func TestLoggerCloseFlushesAsync(t *testing.T) {
+	dir := t.TempDir()
+	l := NewLogger(Dir(dir), Filename("service.log"), Async())
+	_ = l.Log(log.LevelInfo, "msg", "last words")
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(filepath.Join(dir, "service.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "msg=\"last words\" level=INFO\n" {
+		t.Fatalf("got %q", b)
+	}
+}
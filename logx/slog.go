@@ -0,0 +1,257 @@
+package logx
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// SlogLevelFatal is the slog level mapped to log.LevelFatal. slog has no
+// fatal level, levels from LevelError+4 on are logged as FATAL.
+const SlogLevelFatal = slog.LevelError + 4
+
+// FromSlogLevel maps an slog level to the kratos level containing it.
+func FromSlogLevel(l slog.Level) log.Level {
+	switch {
+	case l < slog.LevelInfo:
+		return log.LevelDebug
+	case l < slog.LevelWarn:
+		return log.LevelInfo
+	case l < slog.LevelError:
+		return log.LevelWarn
+	case l < SlogLevelFatal:
+		return log.LevelError
+	default:
+		return log.LevelFatal
+	}
+}
+
+// ToSlogLevel maps a kratos level to slog.
+func ToSlogLevel(l log.Level) slog.Level {
+	switch l {
+	case log.LevelDebug:
+		return slog.LevelDebug
+	case log.LevelWarn:
+		return slog.LevelWarn
+	case log.LevelError:
+		return slog.LevelError
+	case log.LevelFatal:
+		return SlogLevelFatal
+	default:
+		return slog.LevelInfo
+	}
+}
+
+var _ slog.Handler = (*SlogHandler)(nil)
+
+// SlogOption is SlogHandler option.
+type SlogOption func(*SlogHandler)
+
+// SlogLeveler sets the level the handler reports as enabled. By default it
+// is the level of the *Logger passed to NewSlogHandler, or every level for
+// other loggers.
+func SlogLeveler(al *AtomicLevel) SlogOption {
+	return func(h *SlogHandler) {
+		h.enabled = al.Enabled
+	}
+}
+
+// SlogAddSource adds the caller of the slog call as the "caller" field. Use
+// it with a logger without the Caller valuer of WithService, whose depth
+// does not fit slog calls.
+func SlogAddSource(add bool) SlogOption {
+	return func(h *SlogHandler) {
+		h.addSource = add
+	}
+}
+
+// SlogContextValues adds fields evaluated with the context of each record,
+// e.g. "trace.id", tracing.TraceID(). The Valuers of loggers built by
+// log.With, such as those of WithService, see the record context as well.
+func SlogContextValues(keyvals ...interface{}) SlogOption {
+	return func(h *SlogHandler) {
+		h.ctxValues = append(h.ctxValues, keyvals...)
+	}
+}
+
+// SlogHandler is an slog.Handler logging through a kratos logger, e.g. one
+// built by SetUpLog. Groups are flattened to dotted keys, "req.id", and the
+// message is logged under DefaultMessageKey. The record time is ignored,
+// the logger adds its own ts field.
+type SlogHandler struct {
+	logger    log.Logger
+	enabled   func(log.Level) bool
+	addSource bool
+	ctxValues []interface{}
+	msgKey    string
+
+	// attrs 为 WithAttrs 预先转换的 keyvals, prefix 为当前 group 前缀
+	attrs  []interface{}
+	prefix string
+}
+
+// NewSlogHandler returns an slog.Handler logging to l.
+func NewSlogHandler(l log.Logger, opts ...SlogOption) *SlogHandler {
+	h := &SlogHandler{
+		logger:  l,
+		enabled: func(log.Level) bool { return true },
+		msgKey:  DefaultMessageKey,
+	}
+	if lx, ok := l.(*Logger); ok {
+		h.enabled = lx.level.Enabled
+	}
+	for _, o := range opts {
+		o(h)
+	}
+	return h
+}
+
+func (h *SlogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.enabled(FromSlogLevel(level))
+}
+
+func (h *SlogHandler) Handle(ctx context.Context, r slog.Record) error {
+	kvs := make([]interface{}, 0, len(h.attrs)+len(h.ctxValues)+2*r.NumAttrs()+4)
+	kvs = append(kvs, h.ctxValues...)
+	if h.addSource && r.PC != 0 {
+		fs := runtime.CallersFrames([]uintptr{r.PC})
+		f, _ := fs.Next()
+		kvs = append(kvs, "caller", shortCaller(f.File, f.Line))
+	}
+	kvs = append(kvs, h.attrs...)
+	kvs = append(kvs, h.msgKey, r.Message)
+	r.Attrs(func(a slog.Attr) bool {
+		kvs = appendAttr(kvs, h.prefix, a)
+		return true
+	})
+
+	l := h.logger
+	if ctx != nil {
+		l = log.WithContext(ctx, l)
+	}
+	if len(h.ctxValues) > 0 {
+		bindContextValues(ctx, kvs[:len(h.ctxValues)])
+	}
+	return l.Log(FromSlogLevel(r.Level), kvs...)
+}
+
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	nh := *h
+	nh.attrs = make([]interface{}, len(h.attrs), len(h.attrs)+2*len(attrs))
+	copy(nh.attrs, h.attrs)
+	for _, a := range attrs {
+		nh.attrs = appendAttr(nh.attrs, h.prefix, a)
+	}
+	return &nh
+}
+
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	nh := *h
+	nh.prefix = h.prefix + name + "."
+	return &nh
+}
+
+// appendAttr appends a as keyvals, flattening groups to dotted keys.
+func appendAttr(kvs []interface{}, prefix string, a slog.Attr) []interface{} {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return kvs
+	}
+	if a.Value.Kind() != slog.KindGroup {
+		return append(kvs, prefix+a.Key, slogValue(a.Value))
+	}
+	if a.Key != "" {
+		prefix += a.Key + "."
+	}
+	for _, ga := range a.Value.Group() {
+		kvs = appendAttr(kvs, prefix, ga)
+	}
+	return kvs
+}
+
+func slogValue(v slog.Value) interface{} {
+	switch v.Kind() {
+	case slog.KindString:
+		return v.String()
+	case slog.KindInt64:
+		return v.Int64()
+	case slog.KindUint64:
+		return v.Uint64()
+	case slog.KindFloat64:
+		return v.Float64()
+	case slog.KindBool:
+		return v.Bool()
+	case slog.KindDuration:
+		return v.Duration()
+	case slog.KindTime:
+		return v.Time()
+	default:
+		return v.Any()
+	}
+}
+
+// bindContextValues replaces the Valuers in keyvals by their value for ctx.
+func bindContextValues(ctx context.Context, keyvals []interface{}) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	for i := 1; i < len(keyvals); i += 2 {
+		if v, ok := keyvals[i].(log.Valuer); ok {
+			keyvals[i] = v(ctx)
+		}
+	}
+}
+
+var _ log.Logger = (*slogLogger)(nil)
+
+// NewSlogLogger returns a kratos logger writing to h, so that a Helper can
+// log through any slog.Handler: NewHelper(NewSlogLogger(h)). The value of
+// DefaultMessageKey becomes the record message.
+func NewSlogLogger(h slog.Handler) log.Logger {
+	return &slogLogger{handler: h, msgKey: DefaultMessageKey}
+}
+
+type slogLogger struct {
+	handler slog.Handler
+	msgKey  string
+}
+
+func (l *slogLogger) Log(level log.Level, keyvals ...interface{}) error {
+	ctx := context.Background()
+	sl := ToSlogLevel(level)
+	if !l.handler.Enabled(ctx, sl) {
+		return nil
+	}
+	var msg string
+	attrs := make([]slog.Attr, 0, len(keyvals)/2+1)
+	for i := 0; i < len(keyvals); i += 2 {
+		if i+1 >= len(keyvals) {
+			attrs = append(attrs, slog.Any("!BADKEY", keyvals[i]))
+			break
+		}
+		key, ok := keyvals[i].(string)
+		if !ok {
+			key = fmt.Sprint(keyvals[i])
+		}
+		if key == l.msgKey && msg == "" {
+			if msg, ok = keyvals[i+1].(string); !ok {
+				msg = fmt.Sprint(keyvals[i+1])
+			}
+			continue
+		}
+		attrs = append(attrs, slog.Any(key, keyvals[i+1]))
+	}
+	r := slog.NewRecord(time.Now(), sl, msg, 0)
+	r.AddAttrs(attrs...)
+	return l.handler.Handle(ctx, r)
+}
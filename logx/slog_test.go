@@ -0,0 +1,80 @@
+package logx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+type ctxKey struct{}
+
+type recordLogger struct {
+	level   log.Level
+	keyvals map[string]interface{}
+}
+
+func (l *recordLogger) Log(level log.Level, keyvals ...interface{}) error {
+	l.level, l.keyvals = level, map[string]interface{}{}
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		l.keyvals[keyvals[i].(string)] = keyvals[i+1]
+	}
+	return nil
+}
+
+func TestSlogHandler(t *testing.T) {
+	rec := &recordLogger{}
+	reqID := func(ctx context.Context) interface{} { return ctx.Value(ctxKey{}) }
+	sl := slog.New(NewSlogHandler(rec,
+		SlogLeveler(NewAtomicLevel(log.LevelInfo)),
+		SlogContextValues("request.id", log.Valuer(reqID)),
+		SlogAddSource(true),
+	))
+
+	sl.Debug("hidden")
+	if rec.keyvals != nil {
+		t.Fatalf("debug entry logged: %v", rec.keyvals)
+	}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "r1")
+	sl.With("user", "bob").WithGroup("req").With("id", 7).
+		ErrorContext(ctx, "failed", slog.Group("db", "ms", 12), "ok", false)
+
+	want := map[string]interface{}{
+		"msg": "failed", "user": "bob", "request.id": "r1",
+		"req.id": int64(7), "req.db.ms": int64(12), "req.ok": false,
+	}
+	for k, v := range want {
+		if rec.keyvals[k] != v {
+			t.Errorf("%s = %v, want %v", k, rec.keyvals[k], v)
+		}
+	}
+	if rec.level != log.LevelError {
+		t.Errorf("level = %v, want ERROR", rec.level)
+	}
+	if c, _ := rec.keyvals["caller"].(string); !strings.Contains(c, "slog_test.go:") {
+		t.Errorf("caller = %v", rec.keyvals["caller"])
+	}
+
+	if FromSlogLevel(SlogLevelFatal) != log.LevelFatal || ToSlogLevel(log.LevelWarn) != slog.LevelWarn {
+		t.Error("level mapping")
+	}
+}
+
+func TestSlogLogger(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewHelper(NewSlogLogger(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})))
+	h.Debug("hidden")
+	h.Infow("msg", "started", "port", 8000)
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("%v: %s", err, buf.String())
+	}
+	if entry["level"] != "INFO" || entry["msg"] != "started" || entry["port"] != float64(8000) {
+		t.Errorf("entry = %v", entry)
+	}
+}
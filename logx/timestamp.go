@@ -0,0 +1,58 @@
+package logx
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/zhaoqiang0201/pkg/clock"
+)
+
+const (
+	TimeFormatRFC3339     = "rfc3339"
+	TimeFormatRFC3339Nano = "rfc3339nano"
+	TimeFormatUnixMilli   = "unix_milli"
+)
+
+// Timestamp returns a log.Valuer that reads the time from c and formats it
+// with format, which is one of the TimeFormat constants or a Go time layout.
+// Unix millis are logged as int64, every other format as a fmt.Stringer
+// printing the formatted time, which keeps the exact time for the gelf, ecs
+// and otlp encodings.
+func Timestamp(c clock.PassiveClock, format string, utc bool) log.Valuer {
+	if c == nil {
+		c = clock.RealClock{}
+	}
+	var layout string
+	switch format {
+	case "", TimeFormatRFC3339:
+		layout = time.RFC3339
+	case TimeFormatRFC3339Nano:
+		layout = time.RFC3339Nano
+	case TimeFormatUnixMilli:
+	default:
+		layout = format
+	}
+	return func(context.Context) interface{} {
+		now := c.Now()
+		if layout == "" {
+			return now.UnixMilli()
+		}
+		if utc {
+			now = now.UTC()
+		}
+		return timestamp{t: now, s: now.Format(layout)}
+	}
+}
+
+// timestamp is the ts field: the time formatted with the configured layout,
+// and the time itself for the encodings with a time field of their own.
+type timestamp struct {
+	t time.Time
+	s string
+}
+
+func (ts timestamp) String() string { return ts.s }
+
+// MarshalText keeps the formatted time for json.Marshal and slog handlers.
+func (ts timestamp) MarshalText() ([]byte, error) { return []byte(ts.s), nil }
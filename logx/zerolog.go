@@ -1,85 +1,247 @@
 package logx
 
 import (
+	"bytes"
 	"fmt"
+	"io"
+	"reflect"
+	"time"
+
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/rs/zerolog"
-	"io"
 )
 
+var _ log.Logger = (*ZeroLog)(nil)
+
+// Default field names of the json encoding.
+const (
+	DefaultTimestampField = "ts"
+	DefaultLevelField     = "level"
+	DefaultMessageField   = "message"
+)
+
+// ZeroOption is ZeroLog option.
+type ZeroOption func(*ZeroLog)
+
+// ZeroLevel sets the minimum level of the zerolog.Logger of the ZeroLog.
+// zerolog.GlobalLevel applies as well.
+func ZeroLevel(level log.Level) ZeroOption {
+	return func(l *ZeroLog) {
+		l.level = level
+	}
+}
+
+// ZeroHook adds hooks to the zerolog.Logger of the ZeroLog. They see the
+// zerolog level of every entry.
+func ZeroHook(hooks ...zerolog.Hook) ZeroOption {
+	return func(l *ZeroLog) {
+		l.hooks = append(l.hooks, hooks...)
+	}
+}
+
+// ZeroFieldNames sets the names of the timestamp, level and message fields.
+// The timestamp is the "ts" field added by WithService. Empty names keep the
+// default.
+func ZeroFieldNames(timestamp, level, message string) ZeroOption {
+	return func(l *ZeroLog) {
+		if timestamp != "" {
+			l.tsKey = timestamp
+		}
+		if level != "" {
+			l.levelKey = level
+		}
+		if message != "" {
+			l.msgKey = message
+		}
+	}
+}
+
+// ZeroLog encodes entries as JSON with zerolog.
 type ZeroLog struct {
-	log zerolog.Logger
+	w        io.Writer
+	level    log.Level
+	hooks    []zerolog.Hook
+	tsKey    string
+	levelKey string
+	msgKey   string
+	zl       zerolog.Logger
 }
 
-func NewZeroLoggerx(w io.Writer) *ZeroLog {
-	l := zerolog.New(w)
-	//switch level {
-	//case log.LevelDebug.String():
-	//	l = l.Level(zerolog.DebugLevel)
-	//case log.LevelInfo.String():
-	//	l = l.Level(zerolog.InfoLevel)
-	//case log.LevelWarn.String():
-	//	l = l.Level(zerolog.WarnLevel)
-	//case log.LevelError.String():
-	//	l = l.Level(zerolog.ErrorLevel)
-	//case log.LevelFatal.String():
-	//	l = l.Level(zerolog.FatalLevel)
-	//default:
-	//	l = l.Level(zerolog.InfoLevel)
-	//}
-	return &ZeroLog{log: l}
+func NewZeroLoggerx(w io.Writer, opts ...ZeroOption) *ZeroLog {
+	l := &ZeroLog{
+		w:        w,
+		level:    log.LevelDebug,
+		tsKey:    DefaultTimestampField,
+		levelKey: DefaultLevelField,
+		msgKey:   DefaultMessageField,
+	}
+	for _, o := range opts {
+		o(l)
+	}
+	l.zl = zerolog.New(zerologWriter{w: w, levelKey: l.levelKey}).Level(toZeroLevel(l.level))
+	for _, h := range l.hooks {
+		l.zl = l.zl.Hook(h)
+	}
+	return l
 }
 
 func (l *ZeroLog) Log(level log.Level, keyvals ...interface{}) error {
 	if len(keyvals) == 0 || len(keyvals)%2 != 0 {
-		l.log.Warn().Msgf("Keyvalues must appear in pairs: ", keyvals)
+		if e := l.zl.WithLevel(zerolog.WarnLevel); e != nil {
+			e.Str(l.msgKey, fmt.Sprintf("Keyvalues must appear in pairs: %v", keyvals)).Send()
+		}
 		return nil
 	}
-	var e *zerolog.Event
-	switch level {
-	case log.LevelDebug:
-		e = l.log.Debug()
-		if !e.Enabled() {
-			return nil
+
+	// WithLevel 不会因 FatalLevel 退出进程
+	e := l.zl.WithLevel(toZeroLevel(level))
+	if e == nil {
+		return nil
+	}
+	var msg interface{}
+	for i := 0; i < len(keyvals); i += 2 {
+		key, ok := keyvals[i].(string)
+		if !ok {
+			key = fmt.Sprint(keyvals[i])
 		}
-	case log.LevelInfo:
-		e = l.log.Info()
-		if !e.Enabled() {
-			return nil
+		switch key {
+		case DefaultMessageKey:
+			msg = keyvals[i+1]
+			continue
+		case DefaultTimestampField:
+			key = l.tsKey
 		}
-	case log.LevelWarn:
-		e = l.log.Warn()
-		if !e.Enabled() {
-			return nil
+		e = appendZeroField(e, key, keyvals[i+1])
+	}
+	if msg != nil {
+		e = appendZeroField(e, l.msgKey, msg)
+	}
+	e.Send()
+	return nil
+}
+
+// appendZeroField adds v to e with the zerolog method matching its type,
+// falling back to reflection for other types.
+func appendZeroField(e *zerolog.Event, key string, v interface{}) *zerolog.Event {
+	switch v := v.(type) {
+	case nil:
+		return e.Interface(key, nil)
+	case string:
+		return e.Str(key, v)
+	case []byte:
+		return e.Bytes(key, v)
+	case bool:
+		return e.Bool(key, v)
+	case int:
+		return e.Int(key, v)
+	case int8:
+		return e.Int8(key, v)
+	case int16:
+		return e.Int16(key, v)
+	case int32:
+		return e.Int32(key, v)
+	case int64:
+		return e.Int64(key, v)
+	case uint:
+		return e.Uint(key, v)
+	case uint8:
+		return e.Uint8(key, v)
+	case uint16:
+		return e.Uint16(key, v)
+	case uint32:
+		return e.Uint32(key, v)
+	case uint64:
+		return e.Uint64(key, v)
+	case float32:
+		return e.Float32(key, v)
+	case float64:
+		return e.Float64(key, v)
+	case time.Time:
+		return e.Time(key, v)
+	case time.Duration:
+		return e.Dur(key, v)
+	case error:
+		if isNilPtr(v) {
+			return e.Interface(key, v)
 		}
-	case log.LevelError:
-		e = l.log.Error()
-		if !e.Enabled() {
-			return nil
+		return e.AnErr(key, v)
+	case fmt.Stringer:
+		if isNilPtr(v) {
+			return e.Interface(key, v)
 		}
+		return e.Stringer(key, v)
 	default:
-		e = l.log.Info()
-		if !e.Enabled() {
-			return nil
-		}
+		return e.Interface(key, v)
 	}
+}
 
-	msg := ""
-	for i := 0; i < len(keyvals); i += 2 {
-		vi := i + 1
-		if fmt.Sprint(keyvals[i]) == "msg" {
-			msg = fmt.Sprint(keyvals[vi])
-			continue
-		}
+// isNilPtr reports whether v is a typed nil pointer, whose methods may panic.
+func isNilPtr(v interface{}) bool {
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Ptr && rv.IsNil()
+}
+
+func toZeroLevel(level log.Level) zerolog.Level {
+	switch level {
+	case log.LevelDebug:
+		return zerolog.DebugLevel
+	case log.LevelWarn:
+		return zerolog.WarnLevel
+	case log.LevelError:
+		return zerolog.ErrorLevel
+	case log.LevelFatal:
+		return zerolog.FatalLevel
+	default:
+		return zerolog.InfoLevel
+	}
+}
 
-		e = e.Any(fmt.Sprint(keyvals[i]), keyvals[vi])
+func fromZeroLevel(level zerolog.Level) log.Level {
+	switch level {
+	case zerolog.TraceLevel, zerolog.DebugLevel:
+		return log.LevelDebug
+	case zerolog.WarnLevel:
+		return log.LevelWarn
+	case zerolog.ErrorLevel:
+		return log.LevelError
+	case zerolog.FatalLevel, zerolog.PanicLevel:
+		return log.LevelFatal
+	default:
+		return log.LevelInfo
 	}
+}
+
+// Close flushes and closes the writer of l, unless it is stdout or stderr.
+func (l *ZeroLog) Close() error {
+	return closeWriter(l.w)
+}
 
-	if msg == "" {
-		e.Send()
+// zerologWriter passes the level of the zerolog entries on to a
+// LevelWriter, and renames the level field, which zerolog always writes
+// first as zerolog.LevelFieldName, to levelKey.
+type zerologWriter struct {
+	w        io.Writer
+	levelKey string
+}
+
+func (w zerologWriter) Write(p []byte) (int, error) {
+	return w.WriteLevel(zerolog.NoLevel, p)
+}
+
+func (w zerologWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	n := len(p)
+	if prefix := `{"` + zerolog.LevelFieldName + `":`; w.levelKey != zerolog.LevelFieldName &&
+		zerolog.LevelFieldName != "" && bytes.HasPrefix(p, []byte(prefix)) {
+		p = append([]byte(`{"`+w.levelKey+`":`), p[len(prefix):]...)
+	}
+	var err error
+	if lw, ok := w.w.(LevelWriter); ok {
+		_, err = lw.WriteLevel(fromZeroLevel(level), p)
 	} else {
-		e.Msg(msg)
+		_, err = w.w.Write(p)
 	}
-
-	return nil
+	if err != nil {
+		return 0, err
+	}
+	return n, nil
 }
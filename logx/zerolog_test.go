@@ -0,0 +1,86 @@
+package logx
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/rs/zerolog"
+)
+
+type levelRecorder struct {
+	bytes.Buffer
+	levels []log.Level
+}
+
+func (w *levelRecorder) WriteLevel(level log.Level, p []byte) (int, error) {
+	w.levels = append(w.levels, level)
+	return w.Write(p)
+}
+
+func TestZeroLog(t *testing.T) {
+	w := &levelRecorder{}
+	l := NewZeroLoggerx(w, ZeroLevel(log.LevelInfo), ZeroFieldNames("@timestamp", "severity", "msg"))
+
+	_ = l.Log(log.LevelDebug, "msg", "hidden")
+	_ = l.Log(log.LevelFatal,
+		"ts", "2026-10-19T10:00:00+08:00",
+		"msg", "boom",
+		"n", 3, "ok", true, "ratio", 0.5,
+		"took", 1500*time.Millisecond,
+		"at", time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+		"err", errors.New("bad"),
+		"raw", []byte("hi"),
+		42, "non-string key",
+	)
+
+	want := `{"severity":"fatal","@timestamp":"2026-10-19T10:00:00+08:00","n":3,"ok":true,"ratio":0.5,` +
+		`"took":1500,"at":"2026-10-19T00:00:00Z","err":"bad","raw":"hi","42":"non-string key","msg":"boom"}` + "\n"
+	if got := w.String(); got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+	if len(w.levels) != 1 || w.levels[0] != log.LevelFatal {
+		t.Errorf("levels = %v, want [FATAL]", w.levels)
+	}
+
+	w.Reset()
+	_ = NewZeroLoggerx(w).Log(log.LevelWarn, "msg", "x")
+	if !strings.HasPrefix(w.String(), `{"level":"warn","message":"x"`) {
+		t.Errorf("default names: %s", w.String())
+	}
+}
+
+type nilStringer struct{ s string }
+
+func (n *nilStringer) String() string { return n.s }
+
+type levelHook struct{ levels []zerolog.Level }
+
+func (h *levelHook) Run(_ *zerolog.Event, level zerolog.Level, _ string) {
+	h.levels = append(h.levels, level)
+}
+
+func TestZeroLogNativeLevels(t *testing.T) {
+	w := &levelRecorder{}
+	hook := &levelHook{}
+	l := NewZeroLoggerx(w, ZeroLevel(log.LevelInfo), ZeroHook(hook))
+
+	_ = l.Log(log.LevelDebug, "msg", "hidden")
+	_ = l.Log(log.LevelError, "msg", "shown", "s", (*nilStringer)(nil), "err", (*stackError)(nil))
+	defer zerolog.SetGlobalLevel(zerolog.GlobalLevel())
+	zerolog.SetGlobalLevel(zerolog.ErrorLevel)
+	_ = l.Log(log.LevelWarn, "msg", "below global level")
+
+	if got, want := w.String(), `{"level":"error","s":null,"err":null,"message":"shown"}`+"\n"; got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+	if len(hook.levels) != 1 || hook.levels[0] != zerolog.ErrorLevel {
+		t.Errorf("hook levels = %v, want [error]", hook.levels)
+	}
+	if len(w.levels) != 1 || w.levels[0] != log.LevelError {
+		t.Errorf("writer levels = %v, want [ERROR]", w.levels)
+	}
+}
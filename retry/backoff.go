@@ -1,6 +1,7 @@
 package retry
 
 import (
+	"context"
 	"github.com/zhaoqiang0201/pkg/clock"
 	"math"
 	"math/rand"
@@ -140,32 +141,76 @@ func NewExponentialBackoffManager(initBackoff, maxbackoff, resetDuration time.Du
 	}
 }
 
+// BackoffUtil calls f with the backoff from backoff between calls until
+// stopCh is closed. If sliding is true, the backoff starts after f returns.
 func BackoffUtil(f func(), backoff BackoffManager, sliding bool, stopCh <-chan struct{}) {
-	var t clock.Timer
-	for {
+	select {
+	case <-stopCh:
+		return
+	default:
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
 		select {
 		case <-stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	BackoffUntil(ctx, func(context.Context) { f() }, backoff, sliding)
+}
+
+// BackoffUntil is like BackoffUtil, but stops as soon as ctx is done,
+// including while waiting for the backoff timer.
+func BackoffUntil(ctx context.Context, f func(context.Context), backoff BackoffManager, sliding bool) {
+	var t clock.Timer
+	for {
+		if ctx.Err() != nil {
 			return
-		default:
 		}
 
 		if !sliding {
 			t = backoff.Backoff()
 		}
 
-		f()
+		f(ctx)
 
 		if sliding {
 			t = backoff.Backoff()
 		}
 
 		select {
-		case <-stopCh:
-			if !t.Stop() {
-				<-t.C()
-			}
+		case <-ctx.Done():
+			clock.StopAndDrain(t)
 			return
 		case <-t.C():
 		}
 	}
 }
+
+// Until calls condition with the delay from fn between calls on c until it
+// returns true or an error, or ctx is done. If sliding is true, the delay
+// starts after condition returns. Waiting ends promptly on cancellation.
+func (fn DelayFunc) Until(ctx context.Context, c clock.Clock, sliding bool, condition func(context.Context) (bool, error)) error {
+	for {
+		var start time.Time
+		if !sliding {
+			start = c.Now()
+		}
+
+		if ok, err := condition(ctx); err != nil || ok {
+			return err
+		}
+
+		d := fn()
+		if !sliding {
+			d -= c.Since(start)
+		}
+		if err := clock.SleepContext(ctx, c, d); err != nil {
+			return err
+		}
+	}
+}
@@ -1,17 +1,75 @@
 package retry
 
 import (
-	"github.com/zhaoqiang0201/pkg/clock"
+	"context"
+	"errors"
+	testingclock "github.com/zhaoqiang0201/pkg/clock/testing"
 	"math"
+	"sync/atomic"
 	"testing"
 	"time"
 )
 
 func TestBackoff(t *testing.T) {
-	backoff := NewExponentialBackoffManager(time.Second, time.Minute, time.Minute*2, math.MaxInt32, 2.0, 1.0, clock.RealClock{})
-	t.Log(">>>>", time.Now())
+	fc := testingclock.NewFakeClock(time.Now())
+	backoff := NewExponentialBackoffManager(time.Second, time.Minute, time.Minute*2, math.MaxInt32, 2.0, 0, fc)
 	stopCh := make(chan struct{})
-	BackoffUtil(func() {
-		t.Log("-->", time.Now())
-	}, backoff, true, stopCh)
+	var calls atomic.Int32
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		BackoffUtil(func() { calls.Add(1) }, backoff, true, stopCh)
+	}()
+	for i := 0; i < 3; i++ {
+		for !fc.HasWaiters() {
+			time.Sleep(time.Millisecond)
+		}
+		fc.Step(time.Minute)
+	}
+	for !fc.HasWaiters() {
+		time.Sleep(time.Millisecond)
+	}
+	close(stopCh)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("BackoffUtil did not return after stopCh was closed")
+	}
+	if got := calls.Load(); got != 4 {
+		t.Fatalf("got %d calls, want 4", got)
+	}
+
+	BackoffUtil(func() { t.Fatal("f called with stopCh closed") }, backoff, true, stopCh)
+}
+
+func TestDelayFuncUntilCanceled(t *testing.T) {
+	fc := testingclock.NewFakeClock(time.Now())
+	ctx, cancel := context.WithCancel(context.Background())
+	delay := Backoff{Duration: time.Minute}.DelayFunc()
+
+	var calls atomic.Int32
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- delay.Until(ctx, fc, true, func(context.Context) (bool, error) {
+			calls.Add(1)
+			return false, nil
+		})
+	}()
+	for !fc.HasWaiters() {
+		time.Sleep(time.Millisecond)
+	}
+	fc.Step(time.Minute)
+	for fc.Waiters() != 1 || calls.Load() != 2 {
+		time.Sleep(time.Millisecond)
+	}
+
+	cancel()
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected Canceled, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Until did not return promptly after cancel")
+	}
 }
@@ -2,7 +2,7 @@ module github.com/zhaoqiang0201/pkg/retry
 
 go 1.20
 
-require github.com/zhaoqiang0201/pkg/clock v0.0.0-20230713160336-d665c3dfe342
+require github.com/zhaoqiang0201/pkg/clock v0.1.0
 
 require (
 	github.com/go-logr/logr v1.2.3 // indirect